@@ -0,0 +1,138 @@
+package ao3
+
+import (
+	"path"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	WorkNotes       = `#workskin > .preface .notes:not(.end) .userstuff`
+	WorkEndNotes    = `#work_endnotes .userstuff`
+	Associations    = `#workskin > .preface ul.associations li`
+	ChildWorks      = `#children ul li`
+	WorkCollections = `dd.collections a`
+)
+
+// WorkAssociations holds a work's links to other works, users and
+// collections. InspiredWorks are the works listed as inspired by this one,
+// which ao3 doesn't tell apart: remixes, podfics, fanart and sequels alike.
+type WorkAssociations struct {
+	Gifts         []string      `yaml:"gifts,omitempty" toml:"gifts,omitempty" json:"gifts,omitempty"`
+	InspiredBy    []RelatedWork `yaml:"inspired_by,omitempty" toml:"inspired_by,omitempty" json:"inspired_by,omitempty"`
+	TranslationOf []RelatedWork `yaml:"translation_of,omitempty" toml:"translation_of,omitempty" json:"translation_of,omitempty"`
+	Translations  []RelatedWork `yaml:"translations,omitempty" toml:"translations,omitempty" json:"translations,omitempty"`
+	InspiredWorks []RelatedWork `yaml:"inspired_works,omitempty" toml:"inspired_works,omitempty" json:"inspired_works,omitempty"`
+	Collections   []Collection  `yaml:"collections,omitempty" toml:"collections,omitempty" json:"collections,omitempty"`
+}
+
+// RelatedWork is a work linked from another work's associations.
+type RelatedWork struct {
+	ID      int      `yaml:"id,omitempty" toml:"id,omitempty" json:"id,omitempty"`
+	Title   string   `yaml:"title" toml:"title" json:"title"`
+	URL     string   `yaml:"url" toml:"url" json:"url"`
+	Authors []string `yaml:"authors,omitempty" toml:"authors,omitempty" json:"authors,omitempty"`
+}
+
+// Collection is an ao3 collection. A work's collections only have a Name,
+// Title and URL; the rest comes from the collection's profile, see
+// ScrapeCollection. A closed collection isn't Open to new works, a moderated
+// one approves them first, and an unrevealed or anonymous one hides its
+// works or their creators. ChallengeType is the kind of challenge, eg Gift
+// Exchange or Prompt Meme, when the collection runs one.
+type Collection struct {
+	Name          string   `yaml:"name" toml:"name" json:"name"`
+	Title         string   `yaml:"title" toml:"title" json:"title"`
+	URL           string   `yaml:"url" toml:"url" json:"url"`
+	Maintainers   []string `yaml:"maintainers,omitempty" toml:"maintainers,omitempty" json:"maintainers,omitempty"`
+	Description   string   `yaml:"description,omitempty" toml:"description,omitempty" json:"description,omitempty"`
+	Open          bool     `yaml:"open,omitempty" toml:"open,omitempty" json:"open,omitempty"`
+	Moderated     bool     `yaml:"moderated,omitempty" toml:"moderated,omitempty" json:"moderated,omitempty"`
+	Unrevealed    bool     `yaml:"unrevealed,omitempty" toml:"unrevealed,omitempty" json:"unrevealed,omitempty"`
+	Anonymous     bool     `yaml:"anonymous,omitempty" toml:"anonymous,omitempty" json:"anonymous,omitempty"`
+	ChallengeType string   `yaml:"challenge_type,omitempty" toml:"challenge_type,omitempty" json:"challenge_type,omitempty"`
+}
+
+func (a WorkAssociations) StringMap() map[string]any {
+	m := make(map[string]any)
+	if v := a.Gifts; len(v) != 0 {
+		m["gifts"] = v
+	}
+	if v := a.InspiredBy; len(v) != 0 {
+		m["inspired_by"] = v
+	}
+	if v := a.TranslationOf; len(v) != 0 {
+		m["translation_of"] = v
+	}
+	if v := a.Translations; len(v) != 0 {
+		m["translations"] = v
+	}
+	if v := a.InspiredWorks; len(v) != 0 {
+		m["inspired_works"] = v
+	}
+	if v := a.Collections; len(v) != 0 {
+		m["collections"] = v
+	}
+	return m
+}
+
+func parseAssociations(doc *goquery.Document) WorkAssociations {
+	var assoc WorkAssociations
+
+	doc.Find(Associations).Each(func(_ int, sel *goquery.Selection) {
+		text := strings.TrimSpace(sel.Text())
+		switch {
+		case strings.HasPrefix(text, "For "):
+			assoc.Gifts = append(assoc.Gifts, parseGifts(sel)...)
+		case strings.HasPrefix(text, "Inspired by"):
+			assoc.InspiredBy = append(assoc.InspiredBy, parseRelatedWork(sel))
+		case strings.HasPrefix(text, "A translation of"):
+			assoc.TranslationOf = append(assoc.TranslationOf, parseRelatedWork(sel))
+		case strings.HasPrefix(text, "Translation into"):
+			assoc.Translations = append(assoc.Translations, parseRelatedWork(sel))
+		}
+	})
+
+	doc.Find(ChildWorks).Each(func(_ int, sel *goquery.Selection) {
+		assoc.InspiredWorks = append(assoc.InspiredWorks, parseRelatedWork(sel))
+	})
+
+	doc.Find(WorkCollections).Each(func(_ int, sel *goquery.Selection) {
+		u := absoluteURL(sel.AttrOr("href", ""))
+		assoc.Collections = append(assoc.Collections, Collection{
+			Name:  path.Base(u),
+			Title: strings.TrimSpace(sel.Text()),
+			URL:   u,
+		})
+	})
+
+	return assoc
+}
+
+// parseGifts returns the recipients of a gift, which are only links when the
+// recipient has an account.
+func parseGifts(sel *goquery.Selection) []string {
+	if links := sel.Find("a"); links.Length() > 0 {
+		return getTextValues(links)
+	}
+	text := strings.TrimSpace(sel.Text())
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "For "), ".")
+	return []string{strings.TrimSpace(text)}
+}
+
+func parseRelatedWork(sel *goquery.Selection) RelatedWork {
+	var rel RelatedWork
+
+	link := sel.Find("a:not([rel=author])").First()
+	rel.URL = absoluteURL(link.AttrOr("href", ""))
+	if id, _ := ParseWorkID(rel.URL); id != 0 {
+		rel.ID = id
+		rel.URL = WorkURL(id)
+	}
+
+	rel.Title = strings.TrimSpace(link.Text())
+	rel.Authors = getTextValues(sel.Find("a[rel=author]"))
+
+	return rel
+}
@@ -0,0 +1,62 @@
+package ao3
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestParseAssociations(t *testing.T) {
+	f, err := os.Open("testdata/work.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	work, err := ParseWorkHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if work.Notes != "<p>Thanks to my beta.</p>" {
+		t.Errorf("got notes %q", work.Notes)
+	}
+	if work.EndNotes != "<p>Thanks for reading!</p>" {
+		t.Errorf("got end notes %q", work.EndNotes)
+	}
+
+	if len(work.Gifts) != 1 || work.Gifts[0] != "friend" {
+		t.Errorf("got gifts %v, expected [friend]", work.Gifts)
+	}
+
+	if len(work.InspiredBy) != 1 {
+		t.Fatalf("got %d inspired by, expected 1", len(work.InspiredBy))
+	}
+	insp := work.InspiredBy[0]
+	if insp.ID != 1112223 || insp.Title != "Other Roads" || insp.URL != WorkURL(1112223) {
+		t.Errorf("got inspired by %+v", insp)
+	}
+	if len(insp.Authors) != 1 || insp.Authors[0] != "otherone" {
+		t.Errorf("got inspired by authors %v, expected [otherone]", insp.Authors)
+	}
+
+	if len(work.Translations) != 1 || work.Translations[0].ID != 5566778 {
+		t.Errorf("got translations %+v", work.Translations)
+	}
+	if len(work.InspiredWorks) != 1 || work.InspiredWorks[0].ID != 49186696 {
+		t.Errorf("got inspired works %+v", work.InspiredWorks)
+	}
+
+	want := []Collection{
+		{Name: "tw_roadtrips", Title: "Teen Wolf Road Trips", URL: "https://archiveofourown.org/collections/tw_roadtrips"},
+		{Name: "sterekfest2015", Title: "Sterek Fest 2015", URL: "https://archiveofourown.org/collections/sterekfest2015"},
+	}
+	if len(work.Collections) != len(want) {
+		t.Fatalf("got collections %+v", work.Collections)
+	}
+	for i, c := range work.Collections {
+		if !reflect.DeepEqual(c, want[i]) {
+			t.Errorf("got collection %+v, expected %+v", c, want[i])
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package ao3
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	Blurb              = `li.work.blurb, li.bookmark.blurb`
+	BlurbTitle         = `h4.heading a:first-of-type`
+	BlurbAuthor        = `h4.heading a[rel=author]`
+	BlurbHeading       = `h4.heading`
+	BlurbFandoms       = `h5.fandoms a.tag`
+	BlurbRating        = `ul.required-tags span.rating`
+	BlurbCategory      = `ul.required-tags span.category`
+	BlurbComplete      = `ul.required-tags span.iswip`
+	BlurbWarnings      = `ul.tags li.warnings a.tag`
+	BlurbRelationships = `ul.tags li.relationships a.tag`
+	BlurbCharacters    = `ul.tags li.characters a.tag`
+	BlurbFreeforms     = `ul.tags li.freeforms a.tag`
+	BlurbSummary       = `blockquote.userstuff.summary`
+	BlurbSeries        = `ul.series li`
+	BlurbDate          = `div.header p.datetime`
+)
+
+// parseBlurbs builds works from the blurbs of a listing, without their
+// chapters, notes or download links. Blurbs of series and external works
+// are left out.
+func parseBlurbs(doc *goquery.Document, podfic bool) []Work {
+	var works []Work
+	doc.Find(Blurb).Each(func(_ int, sel *goquery.Selection) {
+		if w, ok := parseBlurb(sel, podfic); ok {
+			works = append(works, w)
+		}
+	})
+	return works
+}
+
+func parseBlurb(sel *goquery.Selection, podfic bool) (Work, bool) {
+	var work Work
+
+	link := sel.Find(BlurbTitle).First()
+	id, _ := ParseWorkID(link.AttrOr("href", ""))
+	if id == 0 {
+		return work, false
+	}
+	work.setID(id, 0)
+	work.Title = strings.TrimSpace(link.Text())
+
+	auth := blurbAuthors(sel)
+	if podfic {
+		work.Narrators = auth
+	} else {
+		work.Authors = auth
+	}
+
+	work.Meta = WorkMeta{
+		Rating:        firstOf(parseRequired(ratings, splitTitle(sel.Find(BlurbRating).First()))),
+		Warnings:      parseRequired(warnings, getTextValues(sel.Find(BlurbWarnings))),
+		Categories:    parseRequired(categories, splitTitle(sel.Find(BlurbCategory).First())),
+		Fandoms:       getTextValues(sel.Find(BlurbFandoms)),
+		Relationships: getTextValues(sel.Find(BlurbRelationships)),
+		Characters:    getTextValues(sel.Find(BlurbCharacters)),
+		Freeforms:     getTextValues(sel.Find(BlurbFreeforms)),
+		Language:      parseLanguage(sel.Find(Lang).First()),
+	}
+	work.Tags = work.Meta.Freeforms
+	if l := work.Meta.Language; l != "" {
+		work.Languages = []string{l}
+	}
+
+	stats := &work.Stats
+	stats.Updated = parseBlurbDate(sel.Find(BlurbDate).First().Text())
+	stats.Words = parseCount(sel.Find(Words).First().Text())
+	stats.Chapters, stats.ExpectedChapters = parseChapterCount(sel.Find(ChapterCount).First().Text())
+	stats.Comments = parseCount(sel.Find(CommentCount).First().Text())
+	stats.Kudos = parseCount(sel.Find(Kudos).First().Text())
+	stats.Bookmarks = parseCount(sel.Find(Bookmarks).First().Text())
+	stats.Hits = parseCount(sel.Find(Hits).First().Text())
+	stats.Complete = sel.Find(BlurbComplete).HasClass("complete-yes")
+
+	summary, _ := sel.Find(BlurbSummary).First().Html()
+	work.Comments = strings.ReplaceAll(strings.TrimSpace(summary), "\n", "")
+
+	work.setSeries(parseSeriesParts(sel.Find(BlurbSeries)))
+
+	return work, true
+}
+
+// blurbAuthors returns the creators in a blurb's byline.
+func blurbAuthors(sel *goquery.Selection) []string {
+	auth := getTextValues(sel.Find(BlurbAuthor))
+	if len(auth) == 0 {
+		// anonymous and external works have a byline without links, which
+		// is the text after the title link; the title itself may contain "by"
+		by := strings.TrimSpace(strings.TrimPrefix(blurbByline(sel), "by"))
+		if by != "" {
+			auth = []string{by}
+		}
+	}
+	return auth
+}
+
+// blurbByline returns the text of the blurb heading that follows the title
+// link.
+func blurbByline(sel *goquery.Selection) string {
+	title := sel.Find(BlurbTitle).First()
+	var after bool
+	var by strings.Builder
+	sel.Find(BlurbHeading).First().Contents().Each(func(_ int, n *goquery.Selection) {
+		if after {
+			by.WriteString(n.Text())
+		}
+		if n.IsSelection(title) {
+			after = true
+		}
+	})
+	return strings.TrimSpace(by.String())
+}
+
+// splitTitle splits the comma separated title of a required tags icon.
+func splitTitle(sel *goquery.Selection) []string {
+	var vals []string
+	for _, v := range strings.Split(sel.AttrOr("title", ""), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			vals = append(vals, v)
+		}
+	}
+	return vals
+}
+
+// parseBlurbDate reads the date a blurb was last updated, like 15 Aug 2023.
+func parseBlurbDate(s string) time.Time {
+	t, _ := time.Parse("02 Jan 2006", strings.TrimSpace(s))
+	return t
+}
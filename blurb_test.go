@@ -0,0 +1,93 @@
+package ao3
+
+import (
+	"context"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestParseBlurbs(t *testing.T) {
+	works := parseBlurbs(readTestdata(t, "search"), false)
+	if len(works) != 3 {
+		t.Fatalf("got %d works, expected 3", len(works))
+	}
+
+	w := works[0]
+	if w.WorkID != 4455667 || w.Title != "Moonrise" {
+		t.Errorf("got work %d %q, expected 4455667 Moonrise", w.WorkID, w.Title)
+	}
+	if want := []string{"author_a", "Bee (author_b)"}; !slices.Equal(w.Authors, want) {
+		t.Errorf("got authors %v, expected %v", w.Authors, want)
+	}
+	if w.Meta.Rating != RatingMature {
+		t.Errorf("got rating %q, expected Mature", w.Meta.Rating)
+	}
+	if want := []Category{CategoryFM, CategoryMM}; !slices.Equal(w.Meta.Categories, want) {
+		t.Errorf("got categories %v, expected %v", w.Meta.Categories, want)
+	}
+	if len(w.Meta.Warnings) != 2 || len(w.Meta.Relationships) != 2 || len(w.Meta.Freeforms) != 1 {
+		t.Errorf("got tags %+v", w.Meta)
+	}
+	if w.Meta.Language != "en" {
+		t.Errorf("got language %q, expected en", w.Meta.Language)
+	}
+	s := w.Stats
+	if s.Words != 45000 || s.ChapterString() != "5/?" || s.Complete || s.Kudos != 3210 || s.Hits != 98765 {
+		t.Errorf("got stats %+v", s)
+	}
+	if want := time.Date(2023, time.August, 15, 0, 0, 0, 0, time.UTC); !s.Updated.Equal(want) {
+		t.Errorf("got updated %v, expected %v", s.Updated, want)
+	}
+	if !strings.Contains(w.Comments, "The moon rises.") {
+		t.Errorf("got summary %q", w.Comments)
+	}
+
+	if w := works[1]; w.Series != "Home Again" || w.SeriesIndex != 2 {
+		t.Errorf("got series %q %v, expected Home Again 2", w.Series, w.SeriesIndex)
+	}
+
+	// the title "Lullaby" contains "by" before the byline
+	w = works[2]
+	if w.Title != "Lullaby" || !slices.Equal(w.Authors, []string{"Anonymous"}) || !w.Stats.Complete {
+		t.Errorf("got %q by %v and complete %v, expected Lullaby by Anonymous and complete", w.Title, w.Authors, w.Stats.Complete)
+	}
+	if !slices.Equal(w.Languages, []string{"fr"}) {
+		t.Errorf("got languages %v, expected [fr]", w.Languages)
+	}
+
+	works = parseBlurbs(readTestdata(t, "bookmarks"), false)
+	if len(works) != 1 || works[0].WorkID != 3221042 {
+		t.Errorf("got %d bookmarked works, expected only 3221042", len(works))
+	}
+}
+
+func TestWalkListBlurbs(t *testing.T) {
+	var requests int32
+	srv := listingServer(&requests)
+	defer srv.Close()
+
+	opts := DefaultOptions()
+	opts.RateLimit = 60000
+	opts.Jitter = 0
+	opts.Blurbs = true
+	opts.MaxPages = 2
+
+	var ids []int
+	err := NewClient(opts).ListFunc(context.Background(), srv.URL+"/listing", func(w Work) error {
+		ids = append(ids, w.WorkID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{11, 12, 13, 21, 22, 23}; !slices.Equal(ids, want) {
+		t.Errorf("got works %v, expected %v", ids, want)
+	}
+	if n := atomic.LoadInt32(&requests); n != 2 {
+		t.Errorf("got %d requests, expected only the 2 listing pages", n)
+	}
+}
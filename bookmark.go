@@ -0,0 +1,214 @@
+package ao3
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/spf13/cast"
+)
+
+const (
+	BookmarkBlurbs = `li.bookmark.blurb`
+	Bookmarker     = `div.user h5.byline a`
+	BookmarkDate   = `div.user p.datetime`
+	BookmarkRec    = `div.user p.status span.rec`
+	BookmarkHidden = `div.user p.status span.private`
+	BookmarkTags   = `div.user ul.meta.tags a.tag`
+	BookmarkNotes  = `div.user blockquote.userstuff.notes`
+)
+
+var externalWorkIDRegexp = regexp.MustCompile(`/external_works/(\d+)`)
+
+// Bookmark is a user's bookmark of a work, a series or a work hosted
+// elsewhere. Exactly one of Work, Series and External is set.
+type Bookmark struct {
+	ID         int
+	Bookmarker string
+	Date       time.Time
+	Notes      string
+	Tags       []string
+	Rec        bool
+	Private    bool
+
+	Work     *Work
+	Series   *Series
+	External *ExternalWork
+}
+
+// ExternalWork is a work hosted outside ao3 that has been bookmarked on it.
+type ExternalWork struct {
+	ID       int      `yaml:"id" toml:"id" json:"id"`
+	Title    string   `yaml:"title" toml:"title" json:"title"`
+	URL      string   `yaml:"url" toml:"url" json:"url"`
+	Creators []string `yaml:"creators,omitempty" toml:"creators,omitempty" json:"creators,omitempty"`
+	Fandoms  []string `yaml:"fandoms,omitempty" toml:"fandoms,omitempty" json:"fandoms,omitempty"`
+	Summary  string   `yaml:"summary,omitempty" toml:"summary,omitempty" json:"summary,omitempty"`
+}
+
+// BookmarkFunc is called with each bookmark of a listing, in listing order.
+// Returning an error stops the crawl, as with WorkFunc.
+type BookmarkFunc func(Bookmark) error
+
+// ScrapeBookmarks returns every bookmark of the bookmark listing at u with
+// the DefaultClient.
+func ScrapeBookmarks(u string) ([]Bookmark, error) {
+	return DefaultClient().ScrapeBookmarksContext(context.Background(), u)
+}
+
+// ScrapeBookmarksContext is ScrapeBookmarks, returning the bookmarks read
+// so far when ctx is done.
+func ScrapeBookmarksContext(ctx context.Context, u string) ([]Bookmark, error) {
+	return DefaultClient().ScrapeBookmarksContext(ctx, u)
+}
+
+// BookmarksFunc calls fn with every bookmark of the listing at u, with the
+// DefaultClient.
+func BookmarksFunc(ctx context.Context, u string, fn BookmarkFunc) error {
+	return DefaultClient().BookmarksFunc(ctx, u, fn)
+}
+
+func (c *Client) ScrapeBookmarks(u string) ([]Bookmark, error) {
+	return c.ScrapeBookmarksContext(context.Background(), u)
+}
+
+// ScrapeBookmarksContext returns every bookmark of the bookmark listing at u,
+// such as a user's or a tag's bookmarks or a bookmark search, returning the
+// bookmarks read so far when ctx is done.
+func (c *Client) ScrapeBookmarksContext(ctx context.Context, u string) ([]Bookmark, error) {
+	var bookmarks []Bookmark
+	err := c.BookmarksFunc(ctx, u, func(b Bookmark) error {
+		bookmarks = append(bookmarks, b)
+		return nil
+	})
+	return bookmarks, err
+}
+
+// BookmarksFunc calls fn with every bookmark of the listing at u, page
+// after page. Bookmarks are built from their blurbs, so bookmarked works
+// have no chapters, notes or download links.
+func (c *Client) BookmarksFunc(ctx context.Context, u string, fn BookmarkFunc) error {
+	lu, err := ParseUrl(u)
+	if err != nil {
+		return err
+	}
+	return c.crawl(ctx, lu, func(_ context.Context, _ Fetcher, _ string, doc *goquery.Document, _, limit int) (int, bool, error) {
+		bookmarks := parseBookmarks(doc, c.opts.Podfic)
+		if limit > 0 && len(bookmarks) > limit {
+			bookmarks = bookmarks[:limit]
+		}
+		for i, b := range bookmarks {
+			switch err := fn(b); {
+			case err == StopCrawl:
+				return i + 1, true, nil
+			case err != nil:
+				return i + 1, true, err
+			}
+		}
+		return len(bookmarks), false, nil
+	})
+}
+
+// ParseBookmarksHTML reads the bookmarks of a saved bookmark listing.
+func ParseBookmarksHTML(r io.Reader) ([]Bookmark, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return []Bookmark{}, err
+	}
+	if err := CheckPage(doc); err != nil {
+		return []Bookmark{}, err
+	}
+	return parseBookmarks(doc, false), nil
+}
+
+// StringMap converts a bookmark to map[string]any, with the bookmarked
+// work, series or external work under "work", "series" or "external_work".
+func (b Bookmark) StringMap() map[string]any {
+	m := map[string]any{
+		"rec":     b.Rec,
+		"private": b.Private,
+	}
+	if v := b.ID; v != 0 {
+		m["bookmark_id"] = v
+	}
+	if v := b.Bookmarker; v != "" {
+		m["bookmarker"] = v
+	}
+	if v := b.Date; !v.IsZero() {
+		m["date"] = v
+	}
+	if v := b.Notes; v != "" {
+		m["notes"] = v
+	}
+	if v := b.Tags; len(v) != 0 {
+		m["tags"] = v
+	}
+	switch {
+	case b.Work != nil:
+		m["work"] = b.Work.StringMap()
+	case b.Series != nil:
+		m["series"] = b.Series.StringMap()
+	case b.External != nil:
+		m["external_work"] = *b.External
+	}
+	return m
+}
+
+func parseBookmarks(doc *goquery.Document, podfic bool) []Bookmark {
+	var bookmarks []Bookmark
+	doc.Find(BookmarkBlurbs).Each(func(_ int, sel *goquery.Selection) {
+		if b, ok := parseBookmark(sel, podfic); ok {
+			bookmarks = append(bookmarks, b)
+		}
+	})
+	return bookmarks
+}
+
+// parseBookmark reads a bookmark blurb, which is the blurb of what was
+// bookmarked followed by the bookmarker's part.
+func parseBookmark(sel *goquery.Selection, podfic bool) (Bookmark, bool) {
+	notes, _ := sel.Find(BookmarkNotes).First().Html()
+	b := Bookmark{
+		ID:         cast.ToInt(strings.TrimPrefix(sel.AttrOr("id", ""), "bookmark_")),
+		Bookmarker: strings.TrimSpace(sel.Find(Bookmarker).First().Text()),
+		Date:       parseBlurbDate(sel.Find(BookmarkDate).First().Text()),
+		Notes:      strings.TrimSpace(notes),
+		Tags:       getTextValues(sel.Find(BookmarkTags)),
+		Rec:        sel.Find(BookmarkRec).Length() > 0,
+		Private:    sel.Find(BookmarkHidden).Length() > 0,
+	}
+
+	if w, ok := parseBlurb(sel, podfic); ok {
+		b.Work = &w
+		return b, true
+	}
+	if s, ok := parseSeriesBlurb(sel); ok {
+		b.Series = &s
+		return b, true
+	}
+	if e, ok := parseExternalWork(sel); ok {
+		b.External = &e
+		return b, true
+	}
+	return b, false
+}
+
+func parseExternalWork(sel *goquery.Selection) (ExternalWork, bool) {
+	link := sel.Find(BlurbTitle).First()
+	m := externalWorkIDRegexp.FindStringSubmatch(link.AttrOr("href", ""))
+	if len(m) == 0 {
+		return ExternalWork{}, false
+	}
+	summary, _ := sel.Find(BlurbSummary).First().Html()
+	return ExternalWork{
+		ID:       cast.ToInt(m[1]),
+		Title:    strings.TrimSpace(link.Text()),
+		URL:      absoluteURL(m[0]),
+		Creators: blurbAuthors(sel),
+		Fandoms:  getTextValues(sel.Find(BlurbFandoms)),
+		Summary:  strings.TrimSpace(summary),
+	}, true
+}
@@ -0,0 +1,100 @@
+package ao3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestParseBookmarksHTML(t *testing.T) {
+	f, err := os.Open("testdata/bookmarks.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	bookmarks, err := ParseBookmarksHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(bookmarks) != 3 {
+		t.Fatalf("got %d bookmarks, expected 3", len(bookmarks))
+	}
+
+	b := bookmarks[0]
+	if b.ID != 7700001 || b.Bookmarker != "reader" || !b.Rec || b.Private {
+		t.Errorf("got bookmark %d by %q, rec %v, private %v", b.ID, b.Bookmarker, b.Rec, b.Private)
+	}
+	if d := b.Date.Format("2006-01-02"); d != "2021-03-03" {
+		t.Errorf("got date %s, expected 2021-03-03", d)
+	}
+	if !slices.Equal(b.Tags, []string{"comfort read", "road trip"}) {
+		t.Errorf("got tags %v", b.Tags)
+	}
+	if b.Notes != "<p>Reread every winter.</p>" {
+		t.Errorf("got notes %q", b.Notes)
+	}
+	if b.Work == nil || b.Work.WorkID != 3221042 || b.Series != nil || b.External != nil {
+		t.Fatalf("got %+v, expected work 3221042", b)
+	}
+	if d := b.Work.Stats.Updated.Format("2006-01-02"); d != "2015-03-02" {
+		t.Errorf("got work updated %s, expected the blurb's 2015-03-02", d)
+	}
+
+	b = bookmarks[1]
+	if b.Series == nil || b.Series.ID != 1331351 || b.Series.Works != 2 || !b.Private || b.Rec {
+		t.Errorf("got %+v, expected private bookmark of series 1331351", b)
+	}
+
+	b = bookmarks[2]
+	if b.External == nil || b.External.ID != 88001 || b.Notes != "<p>Worth the click.</p>" {
+		t.Fatalf("got %+v, expected external work 88001", b)
+	}
+	// the byline comes after the title, which contains "by"
+	if e := b.External; e.Title != "Stand by Me" || !slices.Equal(e.Creators, []string{"offsite writer"}) {
+		t.Errorf("got external work %+v", e)
+	}
+	if _, ok := b.StringMap()["external_work"]; !ok {
+		t.Error("string map missing external_work")
+	}
+}
+
+func TestBookmarksFunc(t *testing.T) {
+	var pages []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages = append(pages, r.URL.Query().Get("page"))
+		http.ServeFile(w, r, "testdata/bookmarks.html")
+	}))
+	defer srv.Close()
+
+	opts := DefaultOptions()
+	opts.RateLimit = 60000
+	opts.Jitter = 0
+	opts.MaxResults = 4
+	c := NewClient(opts)
+
+	var ids []int
+	err := c.BookmarksFunc(context.Background(), srv.URL+"/users/reader/bookmarks", func(b Bookmark) error {
+		ids = append(ids, b.ID)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(ids, []int{7700001, 7700002, 7700003, 7700001}) || !slices.Equal(pages, []string{"1", "2"}) {
+		t.Errorf("got bookmarks %v from pages %v, expected 4 from pages 1 and 2", ids, pages)
+	}
+
+	stop := errors.New("full")
+	err = c.BookmarksFunc(context.Background(), srv.URL+"/users/reader/bookmarks", func(b Bookmark) error {
+		return stop
+	})
+	if err != stop {
+		t.Errorf("got error %v, expected %v", err, stop)
+	}
+}
@@ -0,0 +1,54 @@
+package ao3
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	ChapterList     = `#chapters > div.chapter`
+	ChapterTitle    = `div.preface h3.title`
+	ChapterSummary  = `div.preface .summary .userstuff`
+	ChapterNotes    = `div.preface .notes:not(.end) .userstuff`
+	ChapterEndNotes = `div.preface .end.notes .userstuff`
+	ChapterText     = `div.userstuff.module`
+)
+
+// Chapter is one chapter of a multi-chapter work, in posting order.
+type Chapter struct {
+	ID       int
+	Position int
+	Title    string
+	Summary  string
+	Notes    string
+	EndNotes string
+	Words    int
+}
+
+func parseChapters(doc *goquery.Document) []Chapter {
+	var chapters []Chapter
+	doc.Find(ChapterList).Each(func(i int, sel *goquery.Selection) {
+		title := sel.Find(ChapterTitle).First()
+		_, id := ParseWorkID(title.Find("a").AttrOr("href", ""))
+
+		text := sel.Find(ChapterText).First().Clone()
+		text.Find("h3.landmark").Remove()
+
+		chapters = append(chapters, Chapter{
+			ID:       id,
+			Position: i + 1,
+			Title:    strings.Join(strings.Fields(title.Text()), " "),
+			Summary:  getInnerHTML(sel.Find(ChapterSummary)),
+			Notes:    getInnerHTML(sel.Find(ChapterNotes)),
+			EndNotes: getInnerHTML(sel.Find(ChapterEndNotes)),
+			Words:    len(strings.Fields(text.Text())),
+		})
+	})
+	return chapters
+}
+
+func getInnerHTML(sel *goquery.Selection) string {
+	h, _ := sel.First().Html()
+	return strings.TrimSpace(strings.ReplaceAll(h, "\n", ""))
+}
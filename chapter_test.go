@@ -0,0 +1,46 @@
+package ao3
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseChapters(t *testing.T) {
+	f, err := os.Open("testdata/work.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	work, err := ParseWorkHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(work.Chapters) != 2 {
+		t.Fatalf("got %d chapters, expected 2", len(work.Chapters))
+	}
+
+	want := []Chapter{
+		{
+			ID:       7000001,
+			Position: 1,
+			Title:    "Chapter 1: Departure",
+			Summary:  "<p>They leave Beacon Hills.</p>",
+			Notes:    "<p>Chapter one notes.</p>",
+			EndNotes: "<p>Chapter one end notes.</p>",
+			Words:    7,
+		},
+		{
+			ID:       7000002,
+			Position: 2,
+			Title:    "Chapter 2",
+			Words:    12,
+		},
+	}
+	for i, ch := range work.Chapters {
+		if ch != want[i] {
+			t.Errorf("got chapter %+v, expected %+v", ch, want[i])
+		}
+	}
+}
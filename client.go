@@ -0,0 +1,179 @@
+package ao3
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+var (
+	defaultClient *Client
+	defaultOnce   sync.Once
+)
+
+// Options configures a Client.
+type Options struct {
+	// Podfic scrapes works as podfics: the byline becomes the narrators, and
+	// the authors are taken from the work the podfic is of.
+	Podfic bool
+
+	// Formats are the download extensions wanted, eg ".epub".
+	Formats []string
+
+	// Encode is the extension of the metadata file to write, one of .yaml,
+	// .toml or .json.
+	Encode string
+
+	// Cookies are sent with every request, to browse logged in.
+	Cookies []*http.Cookie
+
+	// Backend names the Fetcher, either HTTPBackend or ChromeBackend.
+	Backend string
+
+	// Workers is how many works are scraped at once. Each worker has its
+	// own tab when scraping with chrome.
+	Workers int
+
+	// Blurbs builds the works of a listing from its blurbs instead of
+	// fetching each one, which is much quicker but leaves out chapters, notes
+	// and download links. DeepFetch, when set, picks the works that are still
+	// fetched in full, eg the ones to download.
+	Blurbs    bool
+	DeepFetch func(Work) bool
+
+	// StartPage is the first page of a listing to crawl, MaxPages how many
+	// pages to crawl from there, and MaxResults how many of the listed works
+	// to scrape. Zero crawls every page from the first.
+	StartPage  int
+	MaxPages   int
+	MaxResults int
+
+	// RateLimit, Jitter, MaxAttempts and Backoff configure the client's
+	// Limiter, see NewLimiter.
+	RateLimit   int
+	Jitter      time.Duration
+	MaxAttempts int
+	Backoff     time.Duration
+
+	// Limiter, when set, is used instead of one made from the settings
+	// above, so several clients can share one rate limit.
+	Limiter *Limiter
+}
+
+// DefaultOptions returns the options the cli starts from: anonymous http
+// scraping of epubs, with yaml metadata and the default delays.
+func DefaultOptions() Options {
+	return Options{
+		Formats:     []string{".epub"},
+		Encode:      ".yaml",
+		Backend:     HTTPBackend,
+		Workers:     DefaultWorkers,
+		RateLimit:   DefaultRateLimit,
+		Jitter:      DefaultJitter,
+		MaxAttempts: DefaultMaxAttempts,
+		Backoff:     DefaultBackoff,
+	}
+}
+
+// Client scrapes ao3 with its own Options. Clients are independent of each
+// other and safe for concurrent use.
+type Client struct {
+	opts     Options
+	limiter  *Limiter
+	download *http.Client
+}
+
+func NewClient(opts Options) *Client {
+	l := opts.Limiter
+	if l == nil {
+		l = NewLimiter(opts.RateLimit, opts.Jitter, opts.MaxAttempts, opts.Backoff)
+	}
+	// downloads can take a while, so only the wait for the response is
+	// bounded and the body is left to the context
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ResponseHeaderTimeout = DefaultTimeout
+	return &Client{
+		opts:     opts,
+		limiter:  l,
+		download: &http.Client{Transport: t},
+	}
+}
+
+// DefaultClient returns the Client behind the package level functions. It
+// uses DefaultOptions, logged in with the cookie file if there is one.
+func DefaultClient() *Client {
+	defaultOnce.Do(func() {
+		opts := DefaultOptions()
+		cookies, err := Cookies()
+		if err == nil {
+			opts.Cookies = cookies
+		}
+		defaultClient = NewClient(opts)
+	})
+	return defaultClient
+}
+
+// Options returns a copy of the client's options.
+func (c *Client) Options() Options {
+	opts := c.opts
+	opts.Formats = append([]string(nil), c.opts.Formats...)
+	opts.Cookies = append([]*http.Cookie(nil), c.opts.Cookies...)
+	return opts
+}
+
+// Limiter returns the Limiter spacing out the client's requests.
+func (c *Client) Limiter() *Limiter {
+	return c.limiter
+}
+
+// NewFetcher returns a Fetcher for the client's backend and cookies. The
+// caller closes it.
+func (c *Client) NewFetcher() (Fetcher, error) {
+	return NewFetcher(c.opts.Backend, c.opts.Cookies...)
+}
+
+// WantsFormat reports whether the download link u is one of the client's
+// formats.
+func (c *Client) WantsFormat(u string) bool {
+	for _, ext := range c.opts.Formats {
+		if strings.Contains(u, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseWorkHTML extracts the metadata from a saved work page.
+func (c *Client) ParseWorkHTML(r io.Reader) (Work, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return Work{}, err
+	}
+	if err := CheckPage(doc); err != nil {
+		return Work{}, err
+	}
+	return parseWork(doc, c.opts.Podfic)
+}
+
+// fetchPage fetches a page through the client's Limiter and checks it isn't
+// an error page.
+func (c *Client) fetchPage(ctx context.Context, f Fetcher, u string) (*goquery.Document, error) {
+	var doc *goquery.Document
+	err := c.limiter.Do(ctx, func() error {
+		var err error
+		doc, err = f.Fetch(ctx, u)
+		if err != nil {
+			return err
+		}
+		return withURL(CheckPage(doc), u)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
@@ -0,0 +1,47 @@
+package ao3
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestClientOptions(t *testing.T) {
+	opts := DefaultOptions()
+	opts.Podfic = true
+	podfic := NewClient(opts)
+	plain := NewClient(DefaultOptions())
+
+	for _, test := range []struct {
+		c         *Client
+		authors   []string
+		narrators []string
+	}{
+		{plain, []string{"someone"}, nil},
+		{podfic, []string{"otherone"}, []string{"someone"}},
+	} {
+		f, err := os.Open("testdata/work.html")
+		if err != nil {
+			t.Fatal(err)
+		}
+		w, err := test.c.ParseWorkHTML(f)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(w.Authors, test.authors) {
+			t.Errorf("got authors %v, expected %v", w.Authors, test.authors)
+		}
+		if !slices.Equal(w.Narrators, test.narrators) {
+			t.Errorf("got narrators %v, expected %v", w.Narrators, test.narrators)
+		}
+	}
+
+	if !plain.WantsFormat("https://archiveofourown.org/downloads/3221042/The_Long_Way_Home.epub") {
+		t.Error("default client doesn't want epubs")
+	}
+	if plain.WantsFormat("https://archiveofourown.org/downloads/3221042/The_Long_Way_Home.pdf") {
+		t.Error("default client wants pdfs")
+	}
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/danielgtaylor/casing"
+	"github.com/ohzqq/ao3"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// collectionCmd represents the collection command
+var collectionCmd = &cobra.Command{
+	Use:     "collection <name> [works|bookmarks]",
+	Aliases: []string{"c"},
+	Short:   "scrape a collection's profile or listings",
+	Long: `scrape a collection's profile, or the works or bookmarked works in it. The
+name can also be the url of any of the collection's pages.`,
+	Args:      cobra.RangeArgs(1, 2),
+	ValidArgs: []string{ao3.WorksListing, ao3.BookmarksListing},
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := newClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx := cmd.Context()
+		name, err := ao3.ParseCollectionName(args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(args) == 1 {
+			col, err := c.ScrapeCollectionContext(ctx, name)
+			reportStatus(name, err)
+			if err != nil || viper.GetBool("no-save") {
+				return
+			}
+			err = writeMetaFile(col.StringMap(), casing.Snake(col.Name)+"_collection", c.Options().Encode)
+			if err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		process := func(w ao3.Work) error {
+			processWork(ctx, c, w)
+			return nil
+		}
+		switch args[1] {
+		case ao3.WorksListing:
+			err = c.CollectionWorksFunc(ctx, name, process)
+		case ao3.BookmarksListing:
+			// only the bookmarked works, like ao3 user <name> bookmarks
+			err = c.ListFunc(ctx, ao3.CollectionURL(name)+"/bookmarks", process)
+		default:
+			log.Fatalf("unknown collection listing %q, expected works or bookmarks", args[1])
+		}
+		if err != nil {
+			reportErrors(name, err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(collectionCmd)
+}
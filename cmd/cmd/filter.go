@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"log"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ohzqq/ao3"
+	"github.com/spf13/cobra"
+)
+
+// filterCmd represents the filter command
+var filterCmd = &cobra.Command{
+	Use:   "filter <tag-url>",
+	Short: "sort and filter the works of a tag",
+	Long: `scrape the works of a tag listing, sorted and filtered by the flags. The
+rating, warning and category flags are filtered on by ao3.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		u, err := filterURL(cmd, args[0])
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		c, err := newListClient(output)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx := cmd.Context()
+		fn, flush, err := resultWriter(ctx, c, output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SortAndFilterFunc(ctx, u, fn)
+		flush()
+		if err != nil {
+			reportErrors(u, err)
+		}
+	},
+}
+
+func init() {
+	flags := filterCmd.Flags()
+	flags.String("any", "", "search within results")
+	flags.StringSlice("tag", nil, "other tags to include")
+	flags.StringSlice("exclude-tag", nil, "other tags to exclude")
+	flags.String("language", "", "language code or name, eg en")
+	flags.String("words", "", "word count, eg 1000-5000 or >1000")
+	flags.String("from", "", "updated from date, eg 2023-01-31")
+	flags.String("to", "", "updated to date, eg 2023-12-31")
+	flags.Bool("complete", false, "only complete works, or only incomplete ones with --complete=false")
+	flags.Bool("crossover", false, "only crossovers, or no crossovers with --crossover=false")
+	flags.String("sort", ao3.SortUpdated, "sort column, eg kudos_count")
+	flags.StringVarP(&output, "output", "o", "table", "write results as [table|json|meta]")
+
+	rootCmd.AddCommand(filterCmd)
+}
+
+// filterURL builds the sort and filter url of the tag at tagURL from the
+// flags.
+func filterURL(cmd *cobra.Command, tagURL string) (string, error) {
+	u, err := ao3.ParseUrl(tagURL)
+	if err != nil {
+		return "", err
+	}
+	params := u.Query()
+
+	// /tags/<name>/works is filtered at /works?tag_id=<name>
+	if p := strings.Split(strings.Trim(u.Path, "/"), "/"); len(p) > 1 && p[0] == "tags" {
+		tag, err := url.PathUnescape(p[1])
+		if err != nil {
+			return "", err
+		}
+		params.Set("tag_id", tag)
+		u.Path = "/works"
+	}
+
+	flags := cmd.Flags()
+	set := func(key, flag string) {
+		if v, _ := flags.GetString(flag); v != "" {
+			params.Set(key, v)
+		}
+	}
+	set("work_search[query]", "any")
+	set("work_search[date_from]", "from")
+	set("work_search[date_to]", "to")
+	set("work_search[sort_column]", "sort")
+
+	if lang, _ := flags.GetString("language"); lang != "" {
+		l, err := ao3.ParseLanguage(lang)
+		if err != nil {
+			return "", err
+		}
+		params.Set("work_search[language_id]", l.Code)
+	}
+	if tags, _ := flags.GetStringSlice("tag"); len(tags) > 0 {
+		params.Set("work_search[other_tag_names]", strings.Join(tags, ","))
+	}
+	if tags, _ := flags.GetStringSlice("exclude-tag"); len(tags) > 0 {
+		params.Set("work_search[excluded_tag_names]", strings.Join(tags, ","))
+	}
+	if words, _ := flags.GetString("words"); words != "" {
+		r := ao3.ParseRange(words)
+		if r.Min > 0 {
+			params.Set("work_search[words_from]", strconv.Itoa(r.Min))
+		}
+		if r.Max > 0 {
+			params.Set("work_search[words_to]", strconv.Itoa(r.Max))
+		}
+	}
+	for _, name := range []string{"complete", "crossover"} {
+		if flags.Changed(name) {
+			v, _ := flags.GetBool(name)
+			params.Set("work_search["+name+"]", map[bool]string{true: "T", false: "F"}[v])
+		}
+	}
+
+	addIDs(params, "include_work_search[rating_ids][]", filter.ratings)
+	addIDs(params, "include_work_search[archive_warning_ids][]", filter.warnings)
+	addIDs(params, "exclude_work_search[archive_warning_ids][]", filter.excludeWarnings)
+	addIDs(params, "include_work_search[category_ids][]", filter.categories)
+	addIDs(params, "exclude_work_search[category_ids][]", filter.excludeCategories)
+
+	params.Set("commit", "Sort and Filter")
+	u.RawQuery = params.Encode()
+	return u.String(), nil
+}
+
+func addIDs[T interface{ ID() int }](params url.Values, key string, vals []T) {
+	for _, v := range vals {
+		params.Add(key, strconv.Itoa(v.ID()))
+	}
+}
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"log"
+	"os"
+
+	"github.com/ohzqq/ao3"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// parseCmd represents the parse command
+var parseCmd = &cobra.Command{
+	Use:   "parse <file.html>...",
+	Short: "parse metadata from saved work pages",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		viper.Set("no-downloads", true)
+
+		c, err := newClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var books []ao3.Work
+		for _, name := range args {
+			f, err := os.Open(name)
+			if err != nil {
+				log.Fatal(err)
+			}
+			b, err := c.ParseWorkHTML(f)
+			f.Close()
+			reportStatus(name, err)
+			if err != nil {
+				continue
+			}
+			books = append(books, b)
+		}
+		processMetadata(cmd.Context(), c, books)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(parseCmd)
+}
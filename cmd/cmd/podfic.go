@@ -3,7 +3,6 @@ package cmd
 import (
 	"log"
 
-	"github.com/ohzqq/ao3"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -13,16 +12,25 @@ var podficCmd = &cobra.Command{
 	Use:     "podfic",
 	Aliases: []string{"p"},
 	Short:   "scrape a podfic",
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		viper.Set("podfic", true)
 		viper.Set("no-downloads", true)
 
-		s, err := ao3.Scrape(args[0])
+		c, err := newClient()
 		if err != nil {
 			log.Fatal(err)
 		}
-		processMetadata(s)
+
+		ctx := cmd.Context()
+		for _, u := range args {
+			if ctx.Err() != nil {
+				break
+			}
+			s, err := c.ScrapeContext(ctx, u)
+			reportStatus(u, err)
+			processMetadata(ctx, c, s)
+		}
 	},
 }
 
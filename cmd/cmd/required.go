@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"github.com/ohzqq/ao3"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
+)
+
+// required holds the rating, warning and category flags.
+type required struct {
+	ratings           []ao3.Rating
+	warnings          []ao3.ArchiveWarning
+	excludeWarnings   []ao3.ArchiveWarning
+	categories        []ao3.Category
+	excludeCategories []ao3.Category
+}
+
+var filter required
+
+func init() {
+	rootCmd.PersistentFlags().StringSlice("rating", nil, "only works with these ratings, eg explicit")
+	rootCmd.PersistentFlags().StringSlice("warning", nil, "only works with these archive warnings")
+	rootCmd.PersistentFlags().StringSlice("exclude-warning", nil, "skip works with these archive warnings, eg \"major character death\"")
+	rootCmd.PersistentFlags().StringSlice("category", nil, "only works in these categories, eg m/m")
+	rootCmd.PersistentFlags().StringSlice("exclude-category", nil, "skip works in these categories")
+
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		var err error
+		filter, err = parseRequiredFlags(cmd)
+		return err
+	}
+}
+
+func parseRequiredFlags(cmd *cobra.Command) (required, error) {
+	var (
+		r   required
+		err error
+	)
+	flags := cmd.Flags()
+	if r.ratings, err = parseFlag(flags.GetStringSlice, "rating", ao3.ParseRating); err != nil {
+		return r, err
+	}
+	if r.warnings, err = parseFlag(flags.GetStringSlice, "warning", ao3.ParseArchiveWarning); err != nil {
+		return r, err
+	}
+	if r.excludeWarnings, err = parseFlag(flags.GetStringSlice, "exclude-warning", ao3.ParseArchiveWarning); err != nil {
+		return r, err
+	}
+	if r.categories, err = parseFlag(flags.GetStringSlice, "category", ao3.ParseCategory); err != nil {
+		return r, err
+	}
+	if r.excludeCategories, err = parseFlag(flags.GetStringSlice, "exclude-category", ao3.ParseCategory); err != nil {
+		return r, err
+	}
+	return r, nil
+}
+
+func parseFlag[T any](get func(string) ([]string, error), name string, parse func(string) (T, error)) ([]T, error) {
+	vals, err := get(name)
+	if err != nil {
+		return nil, err
+	}
+	parsed := make([]T, 0, len(vals))
+	for _, v := range vals {
+		p, err := parse(v)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, p)
+	}
+	return parsed, nil
+}
+
+// match reports whether w has one of the wanted ratings, warnings and
+// categories, and none of the excluded ones.
+func (r required) match(w ao3.Work) bool {
+	if len(r.ratings) > 0 && !slices.Contains(r.ratings, w.Meta.Rating) {
+		return false
+	}
+	if len(r.warnings) > 0 && !containsAny(w.Meta.Warnings, r.warnings) {
+		return false
+	}
+	if containsAny(w.Meta.Warnings, r.excludeWarnings) {
+		return false
+	}
+	if len(r.categories) > 0 && !containsAny(w.Meta.Categories, r.categories) {
+		return false
+	}
+	if containsAny(w.Meta.Categories, r.excludeCategories) {
+		return false
+	}
+	return true
+}
+
+func containsAny[T comparable](vals, any []T) bool {
+	for _, v := range any {
+		if slices.Contains(vals, v) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,17 +1,22 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
+	"os/signal"
+	"path"
 	"strings"
+	"text/tabwriter"
 
 	"github.com/BurntSushi/toml"
 	"github.com/danielgtaylor/casing"
 	"github.com/ohzqq/ao3"
 	"github.com/ohzqq/audbk"
-	"github.com/ohzqq/cdb"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
@@ -20,6 +25,7 @@ import (
 var (
 	query  = &ao3.Query{}
 	ffmeta bool
+	toc    bool
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -31,8 +37,13 @@ var rootCmd = &cobra.Command{
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Ctrl-C cancels the command's context, so scrapes stop and write what they
+// have.
 func Execute() {
-	err := rootCmd.Execute()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	err := rootCmd.ExecuteContext(ctx)
 	if err != nil {
 		os.Exit(1)
 	}
@@ -46,6 +57,20 @@ func init() {
 
 	rootCmd.PersistentFlags().BoolP("podfic", "p", false, "scrape podfic url")
 	rootCmd.PersistentFlags().BoolVarP(&ffmeta, "ffmeta", "m", false, "write ffmeta")
+	rootCmd.PersistentFlags().BoolVarP(&toc, "toc", "t", false, "print table of contents")
+
+	rootCmd.PersistentFlags().StringP("backend", "b", ao3.HTTPBackend, "scraping backend [http|chrome]")
+
+	rootCmd.PersistentFlags().Bool("blurbs", false, "build works from listing blurbs, only visiting the ones to download")
+	rootCmd.PersistentFlags().Int("start-page", 1, "first page of a listing to scrape")
+	rootCmd.PersistentFlags().Int("max-pages", 0, "max pages of a listing to scrape, 0 for all")
+	rootCmd.PersistentFlags().Int("limit", 0, "max works of a listing to scrape, 0 for all")
+
+	rootCmd.PersistentFlags().Int("workers", ao3.DefaultWorkers, "works to scrape at once")
+	rootCmd.PersistentFlags().Int("rate-limit", ao3.DefaultRateLimit, "max requests per minute")
+	rootCmd.PersistentFlags().Duration("jitter", ao3.DefaultJitter, "max random delay added between requests")
+	rootCmd.PersistentFlags().Int("max-attempts", ao3.DefaultMaxAttempts, "max tries for a rate limited request")
+	rootCmd.PersistentFlags().Duration("backoff", ao3.DefaultBackoff, "initial wait after being rate limited")
 
 	rootCmd.PersistentFlags().StringP("encode", "e", ".yaml", "encode [.yaml|.toml|.json|.ini]")
 
@@ -55,9 +80,19 @@ func init() {
 
 	viper.BindPFlag("no-save", rootCmd.PersistentFlags().Lookup("no-save"))
 	viper.BindPFlag("no-downloads", rootCmd.PersistentFlags().Lookup("no-downloads"))
-	viper.BindPFlag("podfics", rootCmd.PersistentFlags().Lookup("podfics"))
+	viper.BindPFlag("podfic", rootCmd.PersistentFlags().Lookup("podfic"))
 	viper.BindPFlag("formats", rootCmd.PersistentFlags().Lookup("formats"))
 	viper.BindPFlag("encode", rootCmd.PersistentFlags().Lookup("encode"))
+	viper.BindPFlag("backend", rootCmd.PersistentFlags().Lookup("backend"))
+	viper.BindPFlag("blurbs", rootCmd.PersistentFlags().Lookup("blurbs"))
+	viper.BindPFlag("start-page", rootCmd.PersistentFlags().Lookup("start-page"))
+	viper.BindPFlag("max-pages", rootCmd.PersistentFlags().Lookup("max-pages"))
+	viper.BindPFlag("limit", rootCmd.PersistentFlags().Lookup("limit"))
+	viper.BindPFlag("workers", rootCmd.PersistentFlags().Lookup("workers"))
+	viper.BindPFlag("rate-limit", rootCmd.PersistentFlags().Lookup("rate-limit"))
+	viper.BindPFlag("jitter", rootCmd.PersistentFlags().Lookup("jitter"))
+	viper.BindPFlag("max-attempts", rootCmd.PersistentFlags().Lookup("max-attempts"))
+	viper.BindPFlag("backoff", rootCmd.PersistentFlags().Lookup("backoff"))
 }
 
 func initConfig() {
@@ -68,32 +103,136 @@ func initConfig() {
 	viper.SetDefault("no-downloads", false)
 	viper.SetDefault("formats", []string{".epub"})
 	viper.SetDefault("encode", ".yaml")
+	viper.SetDefault("backend", ao3.HTTPBackend)
+	viper.SetDefault("workers", ao3.DefaultWorkers)
+	viper.SetDefault("rate-limit", ao3.DefaultRateLimit)
+	viper.SetDefault("jitter", ao3.DefaultJitter)
+	viper.SetDefault("max-attempts", ao3.DefaultMaxAttempts)
+	viper.SetDefault("backoff", ao3.DefaultBackoff)
 }
 
-func processMetadata(books []cdb.Book) {
+// newClient configures an ao3.Client from the flags, logged in with the
+// cookie file if there is one.
+func newClient() (*ao3.Client, error) {
+	cookies, err := ao3.Cookies()
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+
+	opts := ao3.Options{
+		Podfic:      viper.GetBool("podfic"),
+		Formats:     viper.GetStringSlice("formats"),
+		Encode:      viper.GetString("encode"),
+		Cookies:     cookies,
+		Backend:     viper.GetString("backend"),
+		Workers:     viper.GetInt("workers"),
+		StartPage:   viper.GetInt("start-page"),
+		MaxPages:    viper.GetInt("max-pages"),
+		MaxResults:  viper.GetInt("limit"),
+		Blurbs:      viper.GetBool("blurbs"),
+		RateLimit:   viper.GetInt("rate-limit"),
+		Jitter:      viper.GetDuration("jitter"),
+		MaxAttempts: viper.GetInt("max-attempts"),
+		Backoff:     viper.GetDuration("backoff"),
+	}
+	if !viper.GetBool("no-downloads") {
+		// download links are only on the work page, which is only worth
+		// fetching for works the filter flags keep
+		opts.DeepFetch = filter.match
+	}
+	return ao3.NewClient(opts), nil
+}
+
+func processMetadata(ctx context.Context, c *ao3.Client, books []ao3.Work) {
 	for _, b := range books {
-		m := b.StringMap()
-		if !ao3.DontSave() {
-			name := casing.Snake(b.Title)
-			err := writeMetaFile(m, name)
+		processWork(ctx, c, b)
+	}
+}
+
+// processWork writes the metadata of b and downloads its formats, as soon as
+// it's scraped, unless the rating, warning or category flags filter it out.
+func processWork(ctx context.Context, c *ao3.Client, b ao3.Work) {
+	if !filter.match(b) {
+		fmt.Fprintf(os.Stderr, "%s: skipped\n", b.CanonicalURL())
+		return
+	}
+
+	opts := c.Options()
+	if toc {
+		printTOC(b)
+	}
+	m := b.StringMap()
+	if !viper.GetBool("no-save") {
+		name := casing.Snake(b.Title)
+		err := writeMetaFile(m, name, opts.Encode)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if opts.Podfic || ffmeta {
+			err := writeFFMeta(m, name)
 			if err != nil {
 				log.Fatal(err)
 			}
-			if ao3.IsPodfic() || ffmeta {
-				err := writeFFMeta(m, name)
-				if err != nil {
-					log.Fatal(err)
-				}
+		}
+	}
+	if !viper.GetBool("no-downloads") {
+		downloadFormats(ctx, c, b)
+	}
+	//err := b.Print(enc, true)
+	//if err != nil {
+	//log.Fatal(err)
+	//}
+}
+
+// reportStatus prints whether scraping u worked, naming the ao3 error page
+// when it didn't.
+func reportStatus(u string, err error) {
+	status := "ok"
+	if err != nil {
+		status = err.Error()
+		for _, e := range []error{
+			ao3.ErrNotFound,
+			ao3.ErrRestricted,
+			ao3.ErrLoginRequired,
+			ao3.ErrAdultGate,
+			ao3.ErrRateLimited,
+			ao3.ErrMaintenance,
+			ao3.ErrHidden,
+		} {
+			if errors.Is(err, e) {
+				status = e.Error()
+				break
 			}
 		}
-		if !ao3.NoDownloads() {
-			downloadFormats(b)
+	}
+	fmt.Fprintf(os.Stderr, "%s: %s\n", u, status)
+}
+
+// reportErrors prints the status of each work that failed while scraping the
+// listing at u.
+func reportErrors(u string, err error) {
+	var errs ao3.ScrapeErrors
+	if !errors.As(err, &errs) {
+		reportStatus(u, err)
+		return
+	}
+	for _, err := range errs {
+		var e *ao3.Error
+		if errors.As(err, &e) && e.URL != "" {
+			reportStatus(e.URL, err)
+			continue
 		}
-		//err := b.Print(enc, true)
-		//if err != nil {
-		//log.Fatal(err)
-		//}
+		reportStatus(u, err)
+	}
+}
+
+func printTOC(w ao3.Work) {
+	fmt.Println(w.Title)
+	tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	for _, ch := range w.Chapters {
+		fmt.Fprintf(tw, "%d.\t%s\t%d words\n", ch.Position, ch.Title, ch.Words)
 	}
+	tw.Flush()
 }
 
 func writeFFMeta(r map[string]any, name string) error {
@@ -110,11 +249,9 @@ func writeFFMeta(r map[string]any, name string) error {
 	return nil
 }
 
-func writeMetaFile(r map[string]any, name string) error {
+func writeMetaFile(r map[string]any, name, enc string) error {
 	var err error
 
-	enc := ao3.Encode()
-
 	if _, ok := r["formats"]; ok {
 		delete(r, "formats")
 	}
@@ -137,14 +274,17 @@ func writeMetaFile(r map[string]any, name string) error {
 	return nil
 }
 
-func downloadFormats(b cdb.Book) {
+func downloadFormats(ctx context.Context, c *ao3.Client, b ao3.Work) {
 	for _, f := range b.Formats {
-		for _, ext := range ao3.Formats() {
-			if strings.Contains(f, ext) {
-				fmt.Printf("downloading %s\n", b.Title+ext)
-				name := casing.Snake(b.Title) + ext
-				ao3.DownloadWork(f, name)
-			}
+		if !c.WantsFormat(f) {
+			continue
+		}
+		ext := path.Ext(strings.SplitN(f, "?", 2)[0])
+		fmt.Printf("downloading %s\n", b.Title+ext)
+		name := casing.Snake(b.Title) + ext
+		err := c.DownloadWorkContext(ctx, f, name)
+		if err != nil {
+			log.Println(err)
 		}
 	}
 }
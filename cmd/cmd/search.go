@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/ohzqq/ao3"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// output is how search and filter results are written: a table, json lines
+// or metadata files.
+var output string
+
+var search ao3.WorkSearch
+
+// searchCmd represents the search command
+var searchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "scrape the results of a work search",
+	Long: `scrape the results of a work search, built from the flags or given whole
+with --url. The rating, warning and category flags narrow the search too.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := newListClient(output)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		ctx := cmd.Context()
+		u, err := searchURL(ctx, c, cmd)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		fn, flush, err := resultWriter(ctx, c, output)
+		if err != nil {
+			log.Fatal(err)
+		}
+		err = c.SearchFunc(ctx, u, fn)
+		flush()
+		if err != nil {
+			reportErrors(u, err)
+		}
+	},
+}
+
+func init() {
+	flags := searchCmd.Flags()
+	flags.StringVar(&search.AnyField, "any", "", "search any field")
+	flags.StringVar(&search.Title, "title", "", "work title")
+	flags.StringVar(&search.Creators, "creators", "", "work creators")
+	flags.StringVar(&search.RevisedAt, "revised", "", "date updated, eg \"< 2 weeks\"")
+	flags.StringSliceVar(&search.Fandoms, "fandom", nil, "fandoms")
+	flags.StringSliceVar(&search.Characters, "character", nil, "characters")
+	flags.StringSliceVar(&search.Relationships, "relationship", nil, "relationships")
+	flags.StringSliceVar(&search.Freeforms, "tag", nil, "additional tags")
+	flags.StringVar(&search.Language, "language", "", "language code or name, eg en")
+	flags.String("words", "", "word count, eg 1000-5000 or >1000")
+	flags.String("hits", "", "hits, eg >1000")
+	flags.String("kudos", "", "kudos, eg >100")
+	flags.String("comments", "", "comments, eg >10")
+	flags.String("bookmarks", "", "bookmarks, eg >10")
+	flags.Bool("complete", false, "only complete works, or only incomplete ones with --complete=false")
+	flags.Bool("crossover", false, "only crossovers, or no crossovers with --crossover=false")
+	flags.BoolVar(&search.SingleChapter, "single-chapter", false, "only single chapter works")
+	flags.StringVar(&search.SortColumn, "sort", ao3.SortBestMatch, "sort column, eg kudos_count")
+	flags.StringVar(&search.SortDirection, "sort-direction", ao3.SortDesc, "sort direction [asc|desc]")
+	flags.Bool("canonical", false, "resolve tags to their canonical tags before searching")
+	flags.StringVarP(&output, "output", "o", "table", "write results as [table|json|meta]")
+
+	rootCmd.AddCommand(searchCmd)
+}
+
+// searchURL returns the --url flag, or else the search built from the other
+// flags, with its tags made canonical when --canonical is set.
+func searchURL(ctx context.Context, c *ao3.Client, cmd *cobra.Command) (string, error) {
+	flags := cmd.Flags()
+	if u, _ := flags.GetString("url"); u != "" {
+		return u, nil
+	}
+
+	for name, r := range map[string]*ao3.Range{
+		"words":     &search.Words,
+		"hits":      &search.Hits,
+		"kudos":     &search.Kudos,
+		"comments":  &search.Comments,
+		"bookmarks": &search.Bookmarks,
+	} {
+		v, _ := flags.GetString(name)
+		*r = ao3.ParseRange(v)
+	}
+	for name, b := range map[string]**bool{
+		"complete":  &search.Complete,
+		"crossover": &search.Crossover,
+	} {
+		if flags.Changed(name) {
+			v, _ := flags.GetBool(name)
+			*b = &v
+		}
+	}
+
+	// ao3 searches a single rating, the rest are filtered out as they're
+	// scraped.
+	if len(filter.ratings) == 1 {
+		search.Rating = filter.ratings[0]
+	}
+	search.Warnings = filter.warnings
+	search.Categories = filter.categories
+
+	if err := search.Validate(); err != nil {
+		return "", err
+	}
+	if canonical, _ := flags.GetBool("canonical"); canonical {
+		s, err := c.CanonicalSearch(ctx, search)
+		if err != nil {
+			return "", err
+		}
+		return s.String(), nil
+	}
+	return search.String(), nil
+}
+
+// newListClient is newClient for the search and filter commands. Only meta
+// output downloads, so with --blurbs table and json output never fetch a
+// work in full.
+func newListClient(format string) (*ao3.Client, error) {
+	if format != "meta" {
+		viper.Set("no-downloads", true)
+	}
+	return newClient()
+}
+
+// resultWriter returns a function writing each work in format, and one to
+// call once they've all been written.
+func resultWriter(ctx context.Context, c *ao3.Client, format string) (ao3.WorkFunc, func(), error) {
+	switch format {
+	case "table":
+		tw := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(tw, "TITLE\tAUTHORS\tRATING\tWORDS\tURL")
+		fn := func(w ao3.Work) error {
+			if filter.match(w) {
+				fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n",
+					w.Title,
+					strings.Join(w.Authors, ", "),
+					w.Meta.Rating,
+					w.Stats.Words,
+					w.CanonicalURL(),
+				)
+			}
+			return nil
+		}
+		return fn, func() { tw.Flush() }, nil
+	case "json":
+		enc := json.NewEncoder(os.Stdout)
+		fn := func(w ao3.Work) error {
+			if !filter.match(w) {
+				return nil
+			}
+			return enc.Encode(w.StringMap())
+		}
+		return fn, func() {}, nil
+	case "meta":
+		fn := func(w ao3.Work) error {
+			processWork(ctx, c, w)
+			return nil
+		}
+		return fn, func() {}, nil
+	}
+	return nil, nil, fmt.Errorf("unknown output %q, expected table, json or meta", format)
+}
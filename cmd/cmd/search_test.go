@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestTableOutputBlurbs(t *testing.T) {
+	var listings, works int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/listing" {
+			atomic.AddInt32(&works, 1)
+			http.NotFound(w, r)
+			return
+		}
+		atomic.AddInt32(&listings, 1)
+		fmt.Fprint(w, `<html><body><ol class="work index group">`)
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, `<li class="work blurb"><h4 class="heading"><a href="/works/%d">work</a></h4></li>`, i)
+		}
+		fmt.Fprint(w, `</ol></body></html>`)
+	}))
+	defer srv.Close()
+
+	defer viper.Reset()
+	viper.Set("blurbs", true)
+	viper.Set("rate-limit", 60000)
+	viper.Set("jitter", 0)
+
+	c, err := newListClient("table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	fn, flush, err := resultWriter(ctx, c, "table")
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = c.ListFunc(ctx, srv.URL+"/listing", fn)
+	flush()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l, w := atomic.LoadInt32(&listings), atomic.LoadInt32(&works); l != 1 || w != 0 {
+		t.Errorf("got %d listing and %d work requests, expected only the listing", l, w)
+	}
+}
@@ -1,27 +1,59 @@
 package cmd
 
 import (
+	"context"
 	"log"
 
+	"github.com/danielgtaylor/casing"
 	"github.com/ohzqq/ao3"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 // seriesCmd represents the series command
 var seriesCmd = &cobra.Command{
 	Use:     "series",
 	Aliases: []string{"s"},
-	Short:   "scrape a series",
+	Short:   "scrape a series and its works",
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		s, err := ao3.Page(args[0])
+		c, err := newClient()
 		if err != nil {
 			log.Fatal(err)
 		}
-		processMetadata(s)
+
+		scrapeSeries(cmd.Context(), c, args[0])
 	},
 }
 
+// scrapeSeries processes each work of the series at u, then writes the
+// series' own metadata.
+func scrapeSeries(ctx context.Context, c *ao3.Client, u string) {
+	var members []ao3.Work
+	s, err := c.SeriesFunc(ctx, u, func(w ao3.Work) error {
+		members = append(members, w)
+		processWork(ctx, c, w)
+		return nil
+	})
+	if err != nil {
+		reportErrors(u, err)
+	}
+	s.Members = members
+	processSeries(s, c.Options().Encode)
+}
+
+// processSeries writes the metadata of the series itself, unless nothing
+// of it was scraped.
+func processSeries(s ao3.Series, enc string) {
+	if s.Title == "" || viper.GetBool("no-save") {
+		return
+	}
+	err := writeMetaFile(s.StringMap(), casing.Snake(s.Title)+"_series", enc)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(seriesCmd)
 }
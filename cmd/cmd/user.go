@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"log"
+
+	"github.com/danielgtaylor/casing"
+	"github.com/ohzqq/ao3"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// userCmd represents the user command
+var userCmd = &cobra.Command{
+	Use:   "user <name> [works|series|bookmarks|gifts]",
+	Short: "scrape a user's profile or listings",
+	Long: `scrape a user's profile, or the works, series, bookmarks or gifts they've
+listed. The name can also be the url of a user or pseud page, and --pseud
+picks one of the user's pseuds.`,
+	Args:      cobra.RangeArgs(1, 2),
+	ValidArgs: []string{ao3.WorksListing, ao3.SeriesListing, ao3.BookmarksListing, ao3.GiftsListing},
+	Run: func(cmd *cobra.Command, args []string) {
+		c, err := newClient()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		u := args[0]
+		if pseud, _ := cmd.Flags().GetString("pseud"); pseud != "" {
+			u = ao3.UserURL(u, pseud)
+		}
+
+		ctx := cmd.Context()
+		if len(args) == 1 {
+			user, err := c.ScrapeUserContext(ctx, u)
+			reportStatus(u, err)
+			if err != nil || viper.GetBool("no-save") {
+				return
+			}
+			err = writeMetaFile(user.StringMap(), casing.Snake(user.Name)+"_profile", c.Options().Encode)
+			if err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		switch listing := args[1]; listing {
+		case ao3.SeriesListing:
+			series, err := c.UserSeries(ctx, u)
+			if err != nil {
+				reportErrors(u, err)
+			}
+			for _, s := range series {
+				if ctx.Err() != nil {
+					break
+				}
+				scrapeSeries(ctx, c, s.URL())
+			}
+		default:
+			err = c.UserListFunc(ctx, u, listing, func(w ao3.Work) error {
+				processWork(ctx, c, w)
+				return nil
+			})
+			if err != nil {
+				reportErrors(u, err)
+			}
+		}
+	},
+}
+
+func init() {
+	userCmd.Flags().String("pseud", "", "only this pseud's works, series, bookmarks or gifts")
+	rootCmd.AddCommand(userCmd)
+}
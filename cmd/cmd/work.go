@@ -3,7 +3,6 @@ package cmd
 import (
 	"log"
 
-	"github.com/ohzqq/ao3"
 	"github.com/spf13/cobra"
 )
 
@@ -12,13 +11,22 @@ var workCmd = &cobra.Command{
 	Use:     "work",
 	Aliases: []string{"w"},
 	Short:   "scrape a work",
-	Args:    cobra.ExactArgs(1),
+	Args:    cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		s, err := ao3.Scrape(args[0])
+		c, err := newClient()
 		if err != nil {
 			log.Fatal(err)
 		}
-		processMetadata(s)
+
+		ctx := cmd.Context()
+		for _, u := range args {
+			if ctx.Err() != nil {
+				break
+			}
+			s, err := c.ScrapeContext(ctx, u)
+			reportStatus(u, err)
+			processMetadata(ctx, c, s)
+		}
 	},
 }
 
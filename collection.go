@@ -0,0 +1,180 @@
+package ao3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	CollectionTitle       = `#main .collection .primary.header h2.heading`
+	CollectionSummary     = `#main .collection .primary.header .summary`
+	CollectionType        = `#main .collection .primary.header p.type`
+	CollectionMaintainers = `#main .collection.profile dl.meta ul.mods a`
+)
+
+var collectionNameRegexp = regexp.MustCompile(`/collections/([^/?#]+)`)
+
+// ScrapeCollection scrapes the profile of the collection named name, or at
+// the collection url name, with the DefaultClient.
+func ScrapeCollection(name string) (Collection, error) {
+	return DefaultClient().ScrapeCollectionContext(context.Background(), name)
+}
+
+// ScrapeCollectionContext is ScrapeCollection, stopping when ctx is done.
+func ScrapeCollectionContext(ctx context.Context, name string) (Collection, error) {
+	return DefaultClient().ScrapeCollectionContext(ctx, name)
+}
+
+// CollectionWorksFunc calls fn with every work in the collection name, with
+// the DefaultClient.
+func CollectionWorksFunc(ctx context.Context, name string, fn WorkFunc) error {
+	return DefaultClient().CollectionWorksFunc(ctx, name, fn)
+}
+
+// CollectionBookmarksFunc calls fn with every bookmark in the collection
+// name, with the DefaultClient.
+func CollectionBookmarksFunc(ctx context.Context, name string, fn BookmarkFunc) error {
+	return DefaultClient().CollectionBookmarksFunc(ctx, name, fn)
+}
+
+func (c *Client) ScrapeCollection(name string) (Collection, error) {
+	return c.ScrapeCollectionContext(context.Background(), name)
+}
+
+// ScrapeCollectionContext scrapes the profile of the collection named name,
+// or at the collection url name.
+func (c *Client) ScrapeCollectionContext(ctx context.Context, name string) (Collection, error) {
+	n, err := ParseCollectionName(name)
+	if err != nil {
+		return Collection{}, err
+	}
+	pu, err := ParseUrl(CollectionURL(n) + "/profile")
+	if err != nil {
+		return Collection{}, err
+	}
+
+	f, err := c.NewFetcher()
+	if err != nil {
+		return Collection{}, err
+	}
+	defer f.Close()
+
+	doc, err := c.fetchPage(ctx, f, pu.String())
+	if err != nil {
+		return Collection{}, err
+	}
+	col := parseCollection(doc)
+	col.Name = n
+	col.URL = CollectionURL(n)
+	return col, nil
+}
+
+// CollectionWorksFunc calls fn with every work in the collection name, page
+// after page, like ListFunc.
+func (c *Client) CollectionWorksFunc(ctx context.Context, name string, fn WorkFunc) error {
+	n, err := ParseCollectionName(name)
+	if err != nil {
+		return err
+	}
+	return c.ListFunc(ctx, CollectionURL(n)+"/works", fn)
+}
+
+// CollectionBookmarksFunc calls fn with every bookmark in the collection
+// name, page after page, like BookmarksFunc.
+func (c *Client) CollectionBookmarksFunc(ctx context.Context, name string, fn BookmarkFunc) error {
+	n, err := ParseCollectionName(name)
+	if err != nil {
+		return err
+	}
+	return c.BookmarksFunc(ctx, CollectionURL(n)+"/bookmarks", fn)
+}
+
+// ParseCollectionHTML reads a saved collection profile page.
+func ParseCollectionHTML(r io.Reader) (Collection, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return Collection{}, err
+	}
+	if err := CheckPage(doc); err != nil {
+		return Collection{}, err
+	}
+	col := parseCollection(doc)
+	if href, ok := doc.Find(CollectionTitle + " a").Attr("href"); ok {
+		col.Name, _ = ParseCollectionName(href)
+		col.URL = CollectionURL(col.Name)
+	}
+	return col, nil
+}
+
+// CollectionURL returns the url of the collection named name.
+func CollectionURL(name string) string {
+	return "https://" + ao3Host + "/collections/" + url.PathEscape(name)
+}
+
+// StringMap converts a collection to map[string]any.
+func (col Collection) StringMap() map[string]any {
+	m := map[string]any{
+		"name":       col.Name,
+		"title":      col.Title,
+		"url":        col.URL,
+		"open":       col.Open,
+		"moderated":  col.Moderated,
+		"unrevealed": col.Unrevealed,
+		"anonymous":  col.Anonymous,
+	}
+	if v := col.Maintainers; len(v) != 0 {
+		m["maintainers"] = v
+	}
+	if v := col.Description; v != "" {
+		m["description"] = v
+	}
+	if v := col.ChallengeType; v != "" {
+		m["challenge_type"] = v
+	}
+	return m
+}
+
+func parseCollection(doc *goquery.Document) Collection {
+	col := Collection{
+		Title:       strings.TrimSpace(doc.Find(CollectionTitle).First().Text()),
+		Description: userstuff(doc.Find(CollectionSummary)),
+		Maintainers: getTextValues(doc.Find(CollectionMaintainers)),
+	}
+
+	// eg (Closed, Moderated, Unrevealed, Anonymous, Gift Exchange Challenge)
+	text := strings.Trim(strings.TrimSpace(doc.Find(CollectionType).First().Text()), "()")
+	for _, t := range strings.Split(text, ",") {
+		switch t = strings.TrimSpace(t); {
+		case t == "Open":
+			col.Open = true
+		case t == "Moderated":
+			col.Moderated = true
+		case t == "Unrevealed":
+			col.Unrevealed = true
+		case t == "Anonymous":
+			col.Anonymous = true
+		case strings.HasSuffix(t, " Challenge"):
+			col.ChallengeType = strings.TrimSuffix(t, " Challenge")
+		}
+	}
+	return col
+}
+
+// ParseCollectionName returns the name of the collection s, which is either
+// its name or the url of one of its pages.
+func ParseCollectionName(s string) (string, error) {
+	if !strings.Contains(s, "/") {
+		return s, nil
+	}
+	m := collectionNameRegexp.FindStringSubmatch(s)
+	if len(m) == 0 {
+		return "", newError(s, "", fmt.Errorf("%w: not a collection url", ErrParse))
+	}
+	return url.PathUnescape(m[1])
+}
@@ -0,0 +1,54 @@
+package ao3
+
+import (
+	"os"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestParseCollectionHTML(t *testing.T) {
+	f, err := os.Open("testdata/collection.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	col, err := ParseCollectionHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if col.Name != "tw_roadtrips" || col.Title != "Teen Wolf Road Trips" || col.URL != "https://archiveofourown.org/collections/tw_roadtrips" {
+		t.Errorf("got collection %q %q %s", col.Name, col.Title, col.URL)
+	}
+	if !slices.Equal(col.Maintainers, []string{"someone", "mapreader"}) {
+		t.Errorf("got maintainers %v", col.Maintainers)
+	}
+	if col.Description != "<p>A yearly exchange of road trip fic.</p>" {
+		t.Errorf("got description %q", col.Description)
+	}
+	if col.Open || !col.Moderated || !col.Unrevealed || !col.Anonymous {
+		t.Errorf("got open %v, moderated %v, unrevealed %v, anonymous %v, expected a closed, moderated, unrevealed, anonymous collection",
+			col.Open, col.Moderated, col.Unrevealed, col.Anonymous)
+	}
+	if col.ChallengeType != "Gift Exchange" {
+		t.Errorf("got challenge %q, expected Gift Exchange", col.ChallengeType)
+	}
+}
+
+func TestParseCollectionName(t *testing.T) {
+	for in, want := range map[string]string{
+		"tw_roadtrips": "tw_roadtrips",
+		"https://archiveofourown.org/collections/tw_roadtrips":              "tw_roadtrips",
+		"https://archiveofourown.org/collections/tw_roadtrips/works?page=2": "tw_roadtrips",
+		"/collections/sterekfest2015/bookmarks":                             "sterekfest2015",
+	} {
+		got, err := ParseCollectionName(in)
+		if err != nil || got != want {
+			t.Errorf("ParseCollectionName(%s) = %q, %v, expected %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseCollectionName("https://archiveofourown.org/works/1"); err == nil {
+		t.Error("got no error for a work url")
+	}
+}
@@ -0,0 +1,95 @@
+package ao3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrRestricted    = errors.New("only available to registered users")
+	ErrLoginRequired = errors.New("login required")
+	ErrAdultGate     = errors.New("adult content warning")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrMaintenance   = errors.New("down for maintenance")
+	ErrHidden        = errors.New("hidden by an admin")
+	ErrParse         = errors.New("parse error")
+	ErrUserListing   = errors.New("unknown user listing")
+)
+
+// Error records the url, and the selector if there was one, of a failed
+// scrape. Err is usually one of the sentinel errors above, so it can be
+// checked with errors.Is. RetryAfter is set when ao3 said how long to wait.
+type Error struct {
+	URL        string
+	Selector   string
+	Err        error
+	RetryAfter time.Duration
+}
+
+func newError(u, sel string, err error) error {
+	return &Error{
+		URL:      u,
+		Selector: sel,
+		Err:      err,
+	}
+}
+
+func (e *Error) Error() string {
+	msg := e.Err.Error()
+	if e.Selector != "" {
+		msg = fmt.Sprintf("%q: %s", e.Selector, msg)
+	}
+	if e.URL != "" {
+		msg = e.URL + ": " + msg
+	}
+	return msg
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// withURL adds the url to an Error that doesn't have one yet.
+func withURL(err error, u string) error {
+	var e *Error
+	if errors.As(err, &e) && e.URL == "" {
+		e.URL = u
+	}
+	return err
+}
+
+// statusError maps an http status code to an error.
+func statusError(u string, code int, h http.Header) error {
+	switch code {
+	case http.StatusNotFound, http.StatusGone:
+		return newError(u, "", ErrNotFound)
+	case http.StatusTooManyRequests, http.StatusServiceUnavailable:
+		return &Error{
+			URL:        u,
+			Err:        ErrRateLimited,
+			RetryAfter: retryAfter(h),
+		}
+	default:
+		return newError(u, "", fmt.Errorf("unexpected status %d", code))
+	}
+}
+
+// ScrapeErrors collects the errors of the works that failed while scraping a
+// listing, in listing order. Each is usually an *Error naming the work's url.
+type ScrapeErrors []error
+
+func (e ScrapeErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "\n")
+}
+
+func (e ScrapeErrors) Unwrap() []error {
+	return e
+}
@@ -0,0 +1,43 @@
+package ao3
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseErr(t *testing.T) {
+	_, err := ParseWorkHTML(strings.NewReader(`<html><body><p>nothing here</p></body></html>`))
+	if !errors.Is(err, ErrParse) {
+		t.Fatalf("got error %v, expected ErrParse", err)
+	}
+
+	var e *Error
+	if !errors.As(err, &e) {
+		t.Fatalf("got error %T, expected *Error", err)
+	}
+	if e.Selector != Title {
+		t.Errorf("got selector %q, expected %q", e.Selector, Title)
+	}
+
+	err = withURL(err, testWork)
+	if e.URL != testWork {
+		t.Errorf("got url %q, expected %q", e.URL, testWork)
+	}
+	if !strings.HasPrefix(err.Error(), testWork) {
+		t.Errorf("error %q missing url", err)
+	}
+}
+
+func TestStatusError(t *testing.T) {
+	tests := map[int]error{
+		404: ErrNotFound,
+		429: ErrRateLimited,
+		503: ErrRateLimited,
+	}
+	for code, want := range tests {
+		if err := statusError(testWork, code, nil); !errors.Is(err, want) {
+			t.Errorf("statusError(%d) = %v, expected %v", code, err, want)
+		}
+	}
+}
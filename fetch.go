@@ -0,0 +1,166 @@
+package ao3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/chromedp/chromedp"
+)
+
+const (
+	HTTPBackend   = "http"
+	ChromeBackend = "chrome"
+)
+
+// DefaultTimeout bounds every http request, whatever its context allows.
+// Downloads only wait this long for their response headers.
+const DefaultTimeout = 2 * time.Minute
+
+// Fetcher retrieves an ao3 page and returns the parsed html document.
+type Fetcher interface {
+	Fetch(ctx context.Context, u string) (*goquery.Document, error)
+	Close()
+}
+
+// NewFetcher returns the Fetcher for the named backend, defaulting to the http
+// backend. Without cookies the fetcher browses anonymously.
+func NewFetcher(backend string, cookies ...*http.Cookie) (Fetcher, error) {
+	switch backend {
+	case HTTPBackend, "":
+		return NewHTTPFetcher(cookies...), nil
+	case ChromeBackend:
+		return NewChromeFetcher(cookies...)
+	default:
+		return nil, fmt.Errorf("unknown backend %q", backend)
+	}
+}
+
+// HTTPFetcher fetches pages with a plain http client, which is enough for
+// ao3's static html.
+type HTTPFetcher struct {
+	client *http.Client
+}
+
+func NewHTTPFetcher(cookies ...*http.Cookie) *HTTPFetcher {
+	jar, _ := cookiejar.New(nil)
+	jar.SetCookies(&url.URL{Scheme: "https", Host: ao3Host}, cookies)
+	return &HTTPFetcher{
+		client: &http.Client{
+			Jar:     jar,
+			Timeout: DefaultTimeout,
+		},
+	}
+}
+
+func (f *HTTPFetcher) Fetch(ctx context.Context, u string) (*goquery.Document, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", strings.TrimPrefix(userAgent, "user-agent="))
+
+	res, err := f.client.Do(req)
+	if err != nil {
+		return nil, newError(u, "", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		// error pages say more than their status code
+		serr := statusError(u, res.StatusCode, res.Header)
+		if doc, err := goquery.NewDocumentFromReader(res.Body); err == nil {
+			doc.Url = res.Request.URL
+			if err := CheckPage(doc); err != nil {
+				var e, se *Error
+				if errors.As(err, &e) && errors.As(serr, &se) {
+					e.RetryAfter = se.RetryAfter
+				}
+				return nil, withURL(err, u)
+			}
+		}
+		return nil, serr
+	}
+
+	doc, err := goquery.NewDocumentFromReader(res.Body)
+	if err != nil {
+		return nil, newError(u, "", fmt.Errorf("%w: %w", ErrParse, err))
+	}
+	doc.Url = res.Request.URL
+	return doc, nil
+}
+
+func (f *HTTPFetcher) Close() {
+	f.client.CloseIdleConnections()
+}
+
+// ChromeFetcher fetches pages through headless chrome, for pages that need
+// javascript. It starts one browser and opens a tab for each fetch, so it can
+// be shared by concurrent workers.
+type ChromeFetcher struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func NewChromeFetcher(cookies ...*http.Cookie) (*ChromeFetcher, error) {
+	alloc, cancelAlloc := chromedp.NewExecAllocator(context.Background(), chromedp.DefaultExecAllocatorOptions[:]...)
+	browser, cancelBrowser := chromedp.NewContext(alloc)
+	f := &ChromeFetcher{
+		ctx: browser,
+		cancel: func() {
+			cancelBrowser()
+			cancelAlloc()
+		},
+	}
+
+	// the first run starts the browser; cookies are shared by its tabs
+	err := chromedp.Run(browser,
+		setCookies("https://"+ao3Host, cookies),
+	)
+	if err != nil {
+		f.cancel()
+		return nil, err
+	}
+
+	return f, nil
+}
+
+func (f *ChromeFetcher) Fetch(ctx context.Context, u string) (*goquery.Document, error) {
+	// chromedp needs the tab's context, so cancel it from ctx by hand.
+	tab, cancel := chromedp.NewContext(f.ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-ctx.Done():
+			cancel()
+		case <-tab.Done():
+		}
+	}()
+
+	var page, loc string
+	err := chromedp.Run(tab,
+		chromedp.Navigate(u),
+		chromedp.Location(&loc),
+		chromedp.OuterHTML("html", &page, chromedp.ByQuery),
+	)
+	if err != nil {
+		return nil, newError(u, "", err)
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(page))
+	if err != nil {
+		return nil, newError(u, "", fmt.Errorf("%w: %w", ErrParse, err))
+	}
+	doc.Url, _ = url.Parse(loc)
+	return doc, nil
+}
+
+func (f *ChromeFetcher) Close() {
+	f.cancel()
+}
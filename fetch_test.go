@@ -0,0 +1,98 @@
+package ao3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestHTTPFetcher(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+
+	f := NewHTTPFetcher()
+	defer f.Close()
+
+	doc, err := f.Fetch(context.Background(), srv.URL+"/work.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	book, err := parseWork(doc, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if book.Title != "The Long Way Home" {
+		t.Errorf("got title %q, expected The Long Way Home", book.Title)
+	}
+	if len(book.Authors) != 1 || book.Authors[0] != "someone" {
+		t.Errorf("got authors %v, expected [someone]", book.Authors)
+	}
+	if len(book.Formats) != 5 {
+		t.Errorf("got %d formats, expected 5", len(book.Formats))
+	}
+	if book.Series != "Home Again" || book.SeriesIndex != 2 {
+		t.Errorf("got series %s %v, expected Home Again 2", book.Series, book.SeriesIndex)
+	}
+
+	_, err = f.Fetch(context.Background(), srv.URL+"/missing.html")
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("got error %v, expected ErrNotFound", err)
+	}
+}
+
+func TestHTTPFetcherCanceled(t *testing.T) {
+	srv := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer srv.Close()
+
+	f := NewHTTPFetcher()
+	defer f.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := f.Fetch(ctx, srv.URL+"/work.html")
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("got error %v, expected context.Canceled", err)
+	}
+}
+
+func TestDownloadWork(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/cut.epub" {
+			w.Header().Set("Content-Length", "100")
+			w.Write([]byte("part"))
+			w.(http.Flusher).Flush()
+			panic(http.ErrAbortHandler)
+		}
+		w.Write([]byte("whole"))
+	}))
+	defer srv.Close()
+
+	opts := DefaultOptions()
+	opts.RateLimit = 60000
+	opts.Jitter = 0
+	opts.MaxAttempts = 1
+	c := NewClient(opts)
+	dir := t.TempDir()
+
+	name := filepath.Join(dir, "whole.epub")
+	if err := c.DownloadWorkContext(context.Background(), srv.URL+"/whole.epub", name); err != nil {
+		t.Fatal(err)
+	}
+	if b, err := os.ReadFile(name); err != nil || string(b) != "whole" {
+		t.Errorf("got %q, %v, expected the whole download", b, err)
+	}
+
+	name = filepath.Join(dir, "cut.epub")
+	if err := c.DownloadWorkContext(context.Background(), srv.URL+"/cut.epub", name); err == nil {
+		t.Error("got no error for a cut off download")
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("got %d files, expected a cut off download to leave none", len(entries)-1)
+	}
+}
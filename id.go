@@ -0,0 +1,102 @@
+package ao3
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/spf13/cast"
+)
+
+const (
+	WorkLinks       = `ul.work.navigation a, li.download a`
+	SelectedChapter = `#selected_id option[selected]`
+)
+
+var (
+	workIDRegexp    = regexp.MustCompile(`/(?:works|downloads)/(?P<work>\d+)(?:/chapters/(?P<chapter>\d+))?`)
+	chapterIDRegexp = regexp.MustCompile(`^/chapters/(?P<chapter>\d+)`)
+	seriesIDRegexp  = regexp.MustCompile(`/series/(\d+)`)
+)
+
+// ParseWorkID returns the work and chapter ids from a work url, or 0 for any
+// that are missing.
+func ParseWorkID(u string) (int, int) {
+	var work, chapter int
+
+	pu, err := url.Parse(u)
+	if err != nil {
+		return work, chapter
+	}
+	if m := workIDRegexp.FindStringSubmatch(pu.Path); len(m) > 0 {
+		work = cast.ToInt(m[workIDRegexp.SubexpIndex("work")])
+		chapter = cast.ToInt(m[workIDRegexp.SubexpIndex("chapter")])
+	} else if m := chapterIDRegexp.FindStringSubmatch(pu.Path); len(m) > 0 {
+		chapter = cast.ToInt(m[chapterIDRegexp.SubexpIndex("chapter")])
+	}
+
+	return work, chapter
+}
+
+// WorkURL returns the canonical url for a work id.
+func WorkURL(id int) string {
+	return "https://" + ao3Host + "/works/" + strconv.Itoa(id)
+}
+
+// ParseSeriesID returns the series id from a series url, or 0 when it
+// isn't one.
+func ParseSeriesID(u string) int {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return 0
+	}
+	if m := seriesIDRegexp.FindStringSubmatch(pu.Path); len(m) > 0 {
+		return cast.ToInt(m[1])
+	}
+	return 0
+}
+
+// SeriesURL returns the canonical url for a series id.
+func SeriesURL(id int) string {
+	return "https://" + ao3Host + "/series/" + strconv.Itoa(id)
+}
+
+// CanonicalURL returns the work's canonical url.
+func (w Work) CanonicalURL() string {
+	if w.WorkID == 0 {
+		return ""
+	}
+	return WorkURL(w.WorkID)
+}
+
+// setID records any non-zero work and chapter ids, along with the book
+// identifiers.
+func (w *Work) setID(work, chapter int) {
+	if work != 0 {
+		w.WorkID = work
+	}
+	if chapter != 0 {
+		w.ChapterID = chapter
+	}
+	if w.WorkID == 0 {
+		return
+	}
+	w.Identifiers = []string{
+		"ao3:" + strconv.Itoa(w.WorkID),
+		"url:" + w.CanonicalURL(),
+	}
+}
+
+func parseWorkID(doc *goquery.Document) (int, int) {
+	var work int
+	doc.Find(WorkLinks).EachWithBreak(func(_ int, sel *goquery.Selection) bool {
+		work, _ = ParseWorkID(sel.AttrOr("href", ""))
+		return work == 0
+	})
+
+	chapter := cast.ToInt(strings.TrimSpace(doc.Find(SelectedChapter).AttrOr("value", "")))
+
+	return work, chapter
+}
@@ -0,0 +1,58 @@
+package ao3
+
+import (
+	"os"
+	"testing"
+)
+
+func TestParseWorkID(t *testing.T) {
+	tests := map[string][2]int{
+		testWork: {3221042, 0},
+		`https://archiveofourown.org/works/3221042/chapters/7000002`:           {3221042, 7000002},
+		`/works/3221042?view_full_work=true`:                                   {3221042, 0},
+		`/downloads/3221042/The%20Long%20Way%20Home.epub?updated_at=170000000`: {3221042, 0},
+		`https://archiveofourown.org/chapters/7000002`:                         {0, 7000002},
+		testPage: {0, 0},
+	}
+	for u, want := range tests {
+		work, chapter := ParseWorkID(u)
+		if work != want[0] || chapter != want[1] {
+			t.Errorf("ParseWorkID(%s) = %d, %d, expected %d, %d", u, work, chapter, want[0], want[1])
+		}
+	}
+}
+
+func TestWorkIdentifiers(t *testing.T) {
+	f, err := os.Open("testdata/work.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	work, err := ParseWorkHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if work.WorkID != 3221042 {
+		t.Errorf("got work id %d, expected 3221042", work.WorkID)
+	}
+	if work.CanonicalURL() != testWork {
+		t.Errorf("got url %s, expected %s", work.CanonicalURL(), testWork)
+	}
+
+	want := []string{"ao3:3221042", "url:" + testWork}
+	if len(work.Identifiers) != len(want) {
+		t.Fatalf("got identifiers %v, expected %v", work.Identifiers, want)
+	}
+	for i, id := range work.Identifiers {
+		if id != want[i] {
+			t.Errorf("got identifier %s, expected %s", id, want[i])
+		}
+	}
+
+	m := work.StringMap()
+	if m["work_id"] != 3221042 || m["url"] != testWork {
+		t.Errorf("string map missing work id or url: %v %v", m["work_id"], m["url"])
+	}
+}
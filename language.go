@@ -0,0 +1,95 @@
+package ao3
+
+import (
+	_ "embed"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+//go:embed languages.tsv
+var languagesTSV string
+
+var (
+	languages     []Language
+	languagesOnce sync.Once
+)
+
+// Language is one of the languages a work can be posted in on ao3. Code is
+// ao3's id for it, as used by work_search[language_id], and Tag its BCP-47
+// tag.
+type Language struct {
+	Code   string
+	Tag    string
+	Name   string
+	Native string
+}
+
+// Languages returns ao3's languages.
+func Languages() []Language {
+	languagesOnce.Do(func() {
+		for _, line := range strings.Split(languagesTSV, "\n") {
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			f := strings.Split(line, "\t")
+			if len(f) != 4 {
+				continue
+			}
+			languages = append(languages, Language{
+				Code:   f[0],
+				Tag:    f[1],
+				Name:   f[2],
+				Native: f[3],
+			})
+		}
+	})
+	return languages
+}
+
+// LookupLanguage finds a language by its ao3 code, BCP-47 tag, English or
+// native name, ignoring case.
+func LookupLanguage(s string) (Language, bool) {
+	s = strings.TrimSpace(s)
+	for _, l := range Languages() {
+		if strings.EqualFold(s, l.Code) ||
+			strings.EqualFold(s, l.Tag) ||
+			strings.EqualFold(s, l.Name) ||
+			strings.EqualFold(s, l.Native) {
+			return l, true
+		}
+	}
+	return Language{}, false
+}
+
+// ParseLanguage is LookupLanguage, returning an error for unknown languages.
+func ParseLanguage(s string) (Language, error) {
+	l, ok := LookupLanguage(s)
+	if !ok {
+		return l, fmt.Errorf("language %w: unknown %q", ErrParse, s)
+	}
+	return l, nil
+}
+
+func (l Language) String() string {
+	return l.Native
+}
+
+// parseLanguage reads the BCP-47 tag of a work's language from its lang
+// attribute, or else from its name. Languages missing from the table are
+// kept as ao3 gives them.
+func parseLanguage(sel *goquery.Selection) string {
+	if code, ok := sel.Attr("lang"); ok && code != "" {
+		if l, ok := LookupLanguage(code); ok {
+			return l.Tag
+		}
+		return code
+	}
+	name := strings.TrimSpace(sel.Text())
+	if l, ok := LookupLanguage(name); ok {
+		return l.Tag
+	}
+	return name
+}
@@ -0,0 +1,54 @@
+package ao3
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestLookupLanguage(t *testing.T) {
+	for _, in := range []string{"ptBR", "pt-br", "Brazilian Portuguese", "Português brasileiro"} {
+		l, ok := LookupLanguage(in)
+		if !ok || l.Code != "ptBR" || l.Tag != "pt-BR" {
+			t.Errorf("%q: got %+v, expected Brazilian Portuguese", in, l)
+		}
+	}
+	if _, err := ParseLanguage("elvish"); err == nil {
+		t.Error("parsed an unknown language")
+	}
+
+	for html, want := range map[string]string{
+		`<dd class="language" lang="ptPT">Português europeu</dd>`: "pt-PT",
+		`<dd class="language">中文-普通话 國語</dd>`:                     "zh",
+		`<dd class="language">Toki Pona</dd>`:                     "tok",
+		`<dd class="language">Elvish</dd>`:                        "Elvish",
+	} {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(html))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := parseLanguage(doc.Find(Lang)); got != want {
+			t.Errorf("%s: got %q, expected %q", html, got, want)
+		}
+	}
+
+	s := WorkSearch{Language: "Français"}
+	if err := s.Validate(); err != nil {
+		t.Error(err)
+	}
+	if !strings.Contains(s.String(), "language_id%5D=fr") {
+		t.Errorf("got %s, expected the fr language id", s)
+	}
+	if err := (WorkSearch{Language: "elvish"}).Validate(); err == nil {
+		t.Error("validated an unknown language")
+	}
+
+	u, err := searchURL("https://archiveofourown.org/works/search?work_search%5Blanguage_id%5D=qxx", SearchParams())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := u.Query().Get(searchLangId); got != "qxx" {
+		t.Errorf("got language id %q, expected a pasted url's to be kept", got)
+	}
+}
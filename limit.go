@@ -0,0 +1,125 @@
+package ao3
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultRateLimit   = 12
+	DefaultJitter      = time.Second
+	DefaultMaxAttempts = 5
+	DefaultBackoff     = 10 * time.Second
+)
+
+// Limiter spaces out requests to ao3 and retries the ones that get rate
+// limited. A Limiter is safe for concurrent use, and a rate limited request
+// pauses every request sharing the Limiter.
+type Limiter struct {
+	mu          sync.Mutex
+	interval    time.Duration
+	jitter      time.Duration
+	maxAttempts int
+	backoff     time.Duration
+	next        time.Time
+}
+
+// NewLimiter returns a Limiter allowing rpm requests per minute, each delayed
+// by up to jitter more. Rate limited requests are tried up to maxAttempts
+// times, backing off exponentially from backoff, or for as long as ao3's
+// Retry-After header says if that's longer. Zero rpm, maxAttempts or backoff,
+// or a negative jitter, use the defaults.
+func NewLimiter(rpm int, jitter time.Duration, maxAttempts int, backoff time.Duration) *Limiter {
+	if rpm <= 0 {
+		rpm = DefaultRateLimit
+	}
+	if jitter < 0 {
+		jitter = DefaultJitter
+	}
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultMaxAttempts
+	}
+	if backoff <= 0 {
+		backoff = DefaultBackoff
+	}
+	return &Limiter{
+		interval:    time.Minute / time.Duration(rpm),
+		jitter:      jitter,
+		maxAttempts: maxAttempts,
+		backoff:     backoff,
+	}
+}
+
+// Wait blocks until the next request is allowed.
+func (l *Limiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	at := l.next
+	if at.Before(now) {
+		at = now
+	}
+	l.next = at.Add(l.interval + l.randJitter())
+	l.mu.Unlock()
+
+	return sleepContext(ctx, time.Until(at))
+}
+
+// Do calls fn when the Limiter allows, retrying while it returns
+// ErrRateLimited.
+func (l *Limiter) Do(ctx context.Context, fn func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := l.Wait(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = fn()
+		if err == nil || !errors.Is(err, ErrRateLimited) || attempt >= l.maxAttempts {
+			return err
+		}
+
+		wait := l.backoff << (attempt - 1)
+		var e *Error
+		if errors.As(err, &e) && e.RetryAfter > wait {
+			wait = e.RetryAfter
+		}
+		l.pause(wait)
+	}
+}
+
+// pause holds back every request for at least d.
+func (l *Limiter) pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if at := time.Now().Add(d); at.After(l.next) {
+		l.next = at
+	}
+}
+
+func (l *Limiter) randJitter() time.Duration {
+	if l.jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(l.jitter)))
+}
+
+// retryAfter reads a Retry-After header, given either in seconds or as a
+// date.
+func retryAfter(h http.Header) time.Duration {
+	v := h.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
@@ -0,0 +1,103 @@
+package ao3
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestLimiterWait(t *testing.T) {
+	l := NewLimiter(6000, 0, 1, time.Millisecond)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if d := time.Since(start); d < 20*time.Millisecond {
+		t.Errorf("3 requests took %v, expected at least 20ms", d)
+	}
+}
+
+func TestLimiterRetry(t *testing.T) {
+	l := NewLimiter(6000, 0, 3, time.Millisecond)
+
+	var calls int
+	err := l.Do(context.Background(), func() error {
+		calls++
+		return newError(testWork, "", ErrRateLimited)
+	})
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("got error %v, expected ErrRateLimited", err)
+	}
+	if calls != 3 {
+		t.Errorf("got %d calls, expected 3", calls)
+	}
+
+	calls = 0
+	err = l.Do(context.Background(), func() error {
+		calls++
+		return newError(testWork, "", ErrNotFound)
+	})
+	if !errors.Is(err, ErrNotFound) || calls != 1 {
+		t.Errorf("got error %v after %d calls, expected ErrNotFound after 1", err, calls)
+	}
+}
+
+func TestLimiterRetryAfter(t *testing.T) {
+	var calls int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte("Retry later\n"))
+			return
+		}
+		http.ServeFile(w, r, "testdata/work.html")
+	}))
+	defer srv.Close()
+
+	f := NewHTTPFetcher()
+	defer f.Close()
+
+	_, err := f.Fetch(context.Background(), srv.URL)
+	var e *Error
+	if !errors.As(err, &e) || !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("got error %v, expected ErrRateLimited", err)
+	}
+	if e.RetryAfter != time.Second {
+		t.Errorf("got retry after %v, expected 1s", e.RetryAfter)
+	}
+
+	l := NewLimiter(6000, 0, 2, time.Millisecond)
+	calls = 0
+	start := time.Now()
+	err = l.Do(context.Background(), func() error {
+		_, err := f.Fetch(context.Background(), srv.URL)
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d := time.Since(start); d < time.Second {
+		t.Errorf("retried after %v, expected to wait for Retry-After", d)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	h := make(http.Header)
+	h.Set("Retry-After", "120")
+	if d := retryAfter(h); d != 2*time.Minute {
+		t.Errorf("got %v, expected 2m", d)
+	}
+
+	h.Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	if d := retryAfter(h); d < 59*time.Minute || d > time.Hour {
+		t.Errorf("got %v, expected about 1h", d)
+	}
+}
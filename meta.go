@@ -0,0 +1,209 @@
+package ao3
+
+import (
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/ohzqq/cdb"
+	"github.com/spf13/cast"
+)
+
+const (
+	Ratings      = `dd.rating a`
+	Warnings     = `dd.warning a`
+	Categories   = `dd.category a`
+	Characters   = `dd.character a`
+	Lang         = `dd.language`
+	Words        = `dl.stats dd.words`
+	ChapterCount = `dl.stats dd.chapters`
+	Status       = `dl.stats dd.status`
+	CommentCount = `dl.stats dd.comments`
+	Kudos        = `dl.stats dd.kudos`
+	Bookmarks    = `dl.stats dd.bookmarks`
+	Hits         = `dl.stats dd.hits`
+)
+
+// Work is a scraped work. cdb.Book has no custom fields, so the ao3 specific
+// metadata is kept alongside it and merged in by StringMap.
+type Work struct {
+	cdb.Book
+	WorkID    int
+	ChapterID int
+	Meta      WorkMeta
+	Stats     WorkStats
+	Chapters  []Chapter
+	Notes     string
+	EndNotes  string
+	// SeriesParts are all the series the work is part of. The first is
+	// the book's Series.
+	SeriesParts []SeriesPart
+	WorkAssociations
+}
+
+// WorkMeta holds a work's tags, with each tag category kept separate, and its
+// language as a BCP-47 tag.
+type WorkMeta struct {
+	Rating        Rating
+	Warnings      []ArchiveWarning
+	Categories    []Category
+	Fandoms       []string
+	Relationships []string
+	Characters    []string
+	Freeforms     []string
+	Language      string
+}
+
+// WorkStats holds the numbers from a work's stats block. ExpectedChapters is
+// 0 when the author hasn't set a total.
+type WorkStats struct {
+	Published        time.Time
+	Updated          time.Time
+	Words            int
+	Chapters         int
+	ExpectedChapters int
+	Complete         bool
+	Comments         int
+	Kudos            int
+	Bookmarks        int
+	Hits             int
+}
+
+// StringMap converts a work to map[string]any, adding the ao3 fields to the
+// book's.
+func (w Work) StringMap() map[string]any {
+	m := w.Book.StringMap()
+	if w.WorkID != 0 {
+		m["work_id"] = w.WorkID
+		m["url"] = w.CanonicalURL()
+	}
+	if w.ChapterID != 0 {
+		m["chapter_id"] = w.ChapterID
+	}
+	for k, v := range w.Meta.StringMap() {
+		m[k] = v
+	}
+	for k, v := range w.Stats.StringMap() {
+		m[k] = v
+	}
+	for k, v := range w.WorkAssociations.StringMap() {
+		m[k] = v
+	}
+	if v := w.Notes; v != "" {
+		m["notes"] = v
+	}
+	if v := w.EndNotes; v != "" {
+		m["end_notes"] = v
+	}
+	if len(w.SeriesParts) > 1 {
+		m["other_series"] = w.SeriesParts[1:]
+	}
+	return m
+}
+
+func (m WorkMeta) StringMap() map[string]any {
+	meta := make(map[string]any)
+	if v := m.Rating; v != 0 {
+		meta["content_rating"] = v.String()
+	}
+	if v := m.Warnings; len(v) != 0 {
+		meta["warnings"] = requiredNames(v)
+	}
+	if v := m.Categories; len(v) != 0 {
+		meta["categories"] = requiredNames(v)
+	}
+	if v := m.Fandoms; len(v) != 0 {
+		meta["fandoms"] = v
+	}
+	if v := m.Relationships; len(v) != 0 {
+		meta["relationships"] = v
+	}
+	if v := m.Characters; len(v) != 0 {
+		meta["characters"] = v
+	}
+	return meta
+}
+
+func (s WorkStats) StringMap() map[string]any {
+	stats := make(map[string]any)
+	if v := s.Updated; !v.IsZero() {
+		stats["updated"] = v
+	}
+	if v := s.Words; v != 0 {
+		stats["words"] = v
+	}
+	if v := s.Chapters; v != 0 {
+		stats["chapter_count"] = s.ChapterString()
+	}
+	stats["complete"] = s.Complete
+	if v := s.Comments; v != 0 {
+		stats["comment_count"] = v
+	}
+	if v := s.Kudos; v != 0 {
+		stats["kudos"] = v
+	}
+	if v := s.Bookmarks; v != 0 {
+		stats["bookmarks"] = v
+	}
+	if v := s.Hits; v != 0 {
+		stats["hits"] = v
+	}
+	return stats
+}
+
+// ChapterString formats the chapter count the way ao3 does, e.g. 5/?.
+func (s WorkStats) ChapterString() string {
+	total := "?"
+	if s.ExpectedChapters > 0 {
+		total = cast.ToString(s.ExpectedChapters)
+	}
+	return cast.ToString(s.Chapters) + "/" + total
+}
+
+func parseWorkMeta(doc *goquery.Document) WorkMeta {
+	return WorkMeta{
+		Rating:        firstOf(parseRequired(ratings, getTextValues(doc.Find(Ratings)))),
+		Warnings:      parseRequired(warnings, getTextValues(doc.Find(Warnings))),
+		Categories:    parseRequired(categories, getTextValues(doc.Find(Categories))),
+		Fandoms:       getTextValues(doc.Find(Fandom)),
+		Relationships: getTextValues(doc.Find(Ships)),
+		Characters:    getTextValues(doc.Find(Characters)),
+		Freeforms:     getTextValues(doc.Find(Tags)),
+		Language:      parseLanguage(doc.Find(Lang).First()),
+	}
+}
+
+func parseWorkStats(doc *goquery.Document) WorkStats {
+	var stats WorkStats
+
+	stats.Published = parsePubdate(doc.Find(Pubdate).First().Text())
+	stats.Words = parseCount(doc.Find(Words).First().Text())
+	stats.Chapters, stats.ExpectedChapters = parseChapterCount(doc.Find(ChapterCount).First().Text())
+	stats.Comments = parseCount(doc.Find(CommentCount).First().Text())
+	stats.Kudos = parseCount(doc.Find(Kudos).First().Text())
+	stats.Bookmarks = parseCount(doc.Find(Bookmarks).First().Text())
+	stats.Hits = parseCount(doc.Find(Hits).First().Text())
+
+	stats.Complete = stats.ExpectedChapters > 0 && stats.Chapters == stats.ExpectedChapters
+
+	if d := strings.TrimSpace(doc.Find(Status).First().Text()); d != "" {
+		stats.Updated = parsePubdate(d)
+	} else {
+		stats.Updated = stats.Published
+	}
+
+	return stats
+}
+
+// parseCount reads a number formatted with thousands separators.
+func parseCount(s string) int {
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	return cast.ToInt(s)
+}
+
+// parseChapterCount splits a chapter count like 5/? into the posted and
+// expected chapters.
+func parseChapterCount(s string) (int, int) {
+	posted, expected, _ := strings.Cut(strings.TrimSpace(s), "/")
+	return parseCount(posted), parseCount(expected)
+}
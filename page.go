@@ -0,0 +1,49 @@
+package ao3
+
+import (
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	NotFoundPage = `#main.error-404`
+	LoginPage    = `#main.sessions-new, form#new_user`
+	AdultGate    = `p.caution`
+	FlashError   = `.flash.error, .flash.notice`
+)
+
+// CheckPage classifies ao3's error and interstitial pages, returning the
+// matching error, or nil when the page has content to extract.
+func CheckPage(doc *goquery.Document) error {
+	var u string
+	if doc.Url != nil {
+		u = doc.Url.String()
+	}
+
+	title := strings.ToLower(doc.Find("title").Text())
+	body := strings.TrimSpace(doc.Find("body").Text())
+	lower := strings.ToLower(body)
+
+	switch {
+	case doc.Find(NotFoundPage).Length() > 0,
+		strings.Contains(title, "error 404"):
+		return newError(u, NotFoundPage, ErrNotFound)
+	case strings.EqualFold(body, "retry later"):
+		return newError(u, "", ErrRateLimited)
+	case strings.Contains(title, "maintenance"):
+		return newError(u, "", ErrMaintenance)
+	case doc.Find(LoginPage).Length() > 0:
+		if strings.Contains(lower, "only available to registered users") ||
+			(doc.Url != nil && doc.Url.Query().Get("restricted") == "true") {
+			return newError(u, LoginPage, ErrRestricted)
+		}
+		return newError(u, LoginPage, ErrLoginRequired)
+	case strings.Contains(strings.ToLower(doc.Find(AdultGate).Text()), "adult content"):
+		return newError(u, AdultGate, ErrAdultGate)
+	case strings.Contains(strings.ToLower(doc.Find(FlashError).Text()), "don't have permission"):
+		return newError(u, FlashError, ErrHidden)
+	}
+
+	return nil
+}
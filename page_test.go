@@ -0,0 +1,79 @@
+package ao3
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+func TestCheckPage(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+		html string
+		want error
+	}{
+		{
+			name: "not found",
+			html: `<html><head><title>Error 404 | Archive of Our Own</title></head><body><div id="main" class="error-404 errors region"><h2 class="heading">Error 404</h2><p>The page you were looking for doesn't exist.</p></div></body></html>`,
+			want: ErrNotFound,
+		},
+		{
+			name: "restricted",
+			url:  "https://archiveofourown.org/users/login?restricted=true",
+			html: `<html><body><div id="main" class="sessions-new region"><p>This work is only available to registered users of the Archive.</p><form id="new_user" action="/users/login"></form></div></body></html>`,
+			want: ErrRestricted,
+		},
+		{
+			name: "login",
+			url:  "https://archiveofourown.org/users/login",
+			html: `<html><body><div class="flash notice">Sorry, you don't have permission to access the page you were trying to reach. Please log in.</div><div id="main" class="sessions-new region"><form id="new_user" action="/users/login"></form></div></body></html>`,
+			want: ErrLoginRequired,
+		},
+		{
+			name: "adult",
+			html: `<html><body><div id="main" class="works-show region"><p class="caution">This work could have adult content. If you continue, you have agreed that you are willing to see such content.</p><ul class="actions"><li><a href="/works/1?view_adult=true">Yes, Continue</a></li></ul></div></body></html>`,
+			want: ErrAdultGate,
+		},
+		{
+			name: "retry later",
+			html: "Retry later\n",
+			want: ErrRateLimited,
+		},
+		{
+			name: "maintenance",
+			html: `<html><head><title>Archive of Our Own - Maintenance</title></head><body><p>The Archive is down for maintenance.</p></body></html>`,
+			want: ErrMaintenance,
+		},
+		{
+			name: "hidden",
+			html: `<html><body><div class="flash error">Sorry, you don't have permission to access the page you were trying to reach.</div><div id="main" class="home-index region"></div></body></html>`,
+			want: ErrHidden,
+		},
+	}
+
+	for _, test := range tests {
+		doc, err := goquery.NewDocumentFromReader(strings.NewReader(test.html))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if test.url != "" {
+			doc.Url, _ = url.Parse(test.url)
+		}
+		if err := CheckPage(doc); !errors.Is(err, test.want) {
+			t.Errorf("%s: got %v, expected %v", test.name, err, test.want)
+		}
+	}
+}
+
+func TestCheckPageContent(t *testing.T) {
+	for _, name := range []string{"work", "series", "search"} {
+		doc := readTestdata(t, name)
+		if err := CheckPage(doc); err != nil {
+			t.Errorf("%s: got %v, expected no error", name, err)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package ao3
+
+import (
+	"io"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// ParseWorkHTML extracts the metadata from a saved work page with the
+// DefaultClient.
+func ParseWorkHTML(r io.Reader) (Work, error) {
+	return DefaultClient().ParseWorkHTML(r)
+}
+
+// ParseListHTML returns the work links from a saved search or listing page.
+func ParseListHTML(r io.Reader) ([]string, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return []string{}, err
+	}
+	if err := CheckPage(doc); err != nil {
+		return []string{}, err
+	}
+	return parseLinkList(doc)
+}
+
+// ParseBlurbsHTML builds works from the blurbs of a saved search or listing
+// page, without visiting each work.
+func ParseBlurbsHTML(r io.Reader) ([]Work, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return []Work{}, err
+	}
+	if err := CheckPage(doc); err != nil {
+		return []Work{}, err
+	}
+	return parseBlurbs(doc, false), nil
+}
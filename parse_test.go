@@ -0,0 +1,143 @@
+package ao3
+
+import (
+	"os"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/exp/slices"
+)
+
+func TestParseWorkHTML(t *testing.T) {
+	f, err := os.Open("testdata/work.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	book, err := ParseWorkHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if book.Title != "The Long Way Home" {
+		t.Errorf("got title %q, expected The Long Way Home", book.Title)
+	}
+	if book.Comments != "<p>Stiles drives.</p><p>Derek navigates.</p>" {
+		t.Errorf("got comments %q", book.Comments)
+	}
+	if d := book.Pubdate.Format("2006-01-02"); d != "2015-01-24" {
+		t.Errorf("got pubdate %s, expected 2015-01-24", d)
+	}
+	if len(book.Tags) != 2 {
+		t.Errorf("got tags %v, expected 2 freeforms", book.Tags)
+	}
+}
+
+func TestParseWorkMeta(t *testing.T) {
+	f, err := os.Open("testdata/work.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	work, err := ParseWorkHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	meta := work.Meta
+	if meta.Rating != RatingExplicit {
+		t.Errorf("got rating %q, expected Explicit", meta.Rating)
+	}
+	if !slices.Equal(meta.Warnings, []ArchiveWarning{WarningChoseNotToUse}) ||
+		!slices.Equal(meta.Categories, []Category{CategoryMM}) {
+		t.Errorf("got warnings %v, categories %v", meta.Warnings, meta.Categories)
+	}
+	if len(meta.Fandoms) != 1 || len(meta.Relationships) != 1 || len(meta.Characters) != 2 {
+		t.Errorf("got fandoms %v, relationships %v, characters %v", meta.Fandoms, meta.Relationships, meta.Characters)
+	}
+	if meta.Language != "en" {
+		t.Errorf("got language %q, expected en", meta.Language)
+	}
+
+	stats := work.Stats
+	if stats.Words != 12345 {
+		t.Errorf("got %d words, expected 12345", stats.Words)
+	}
+	if stats.ChapterString() != "2/2" || !stats.Complete {
+		t.Errorf("got chapters %s complete %v, expected 2/2 complete", stats.ChapterString(), stats.Complete)
+	}
+	if d := stats.Updated.Format("2006-01-02"); d != "2015-03-01" {
+		t.Errorf("got updated %s, expected 2015-03-01", d)
+	}
+	if stats.Comments != 45 || stats.Kudos != 1234 || stats.Bookmarks != 123 || stats.Hits != 23456 {
+		t.Errorf("got stats %+v", stats)
+	}
+
+	m := work.StringMap()
+	for _, k := range []string{"content_rating", "warnings", "fandoms", "relationships", "characters", "words", "kudos"} {
+		if _, ok := m[k]; !ok {
+			t.Errorf("string map missing %s", k)
+		}
+	}
+}
+
+func TestParseChapterCount(t *testing.T) {
+	tests := map[string][2]int{
+		"2/2":   {2, 2},
+		"5/?":   {5, 0},
+		"1/1":   {1, 1},
+		"12/30": {12, 30},
+	}
+	for in, want := range tests {
+		posted, expected := parseChapterCount(in)
+		if posted != want[0] || expected != want[1] {
+			t.Errorf("parseChapterCount(%q) = %d, %d, expected %d, %d", in, posted, expected, want[0], want[1])
+		}
+	}
+}
+
+func TestParseListHTML(t *testing.T) {
+	f, err := os.Open("testdata/search.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	links, err := ParseListHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 3 {
+		t.Errorf("got %d links, expected 3", len(links))
+	}
+}
+
+func TestParseBookmarkLinks(t *testing.T) {
+	links, err := parseLinkList(readTestdata(t, "bookmarks"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(links) != 1 {
+		t.Fatalf("got links %v, expected only the work", links)
+	}
+	if id, _ := ParseWorkID(links[0]); id != 3221042 {
+		t.Errorf("got work %d, expected 3221042", id)
+	}
+}
+
+func readTestdata(t *testing.T, name string) *goquery.Document {
+	t.Helper()
+	f, err := os.Open("testdata/" + name + ".html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	doc, err := goquery.NewDocumentFromReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
@@ -0,0 +1,100 @@
+package ao3
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWorkers is how many works a Client scrapes at once.
+const DefaultWorkers = 4
+
+// walkWorks scrapes the works at links with the client's workers, all
+// fetching through f and waiting on the client's Limiter, and calls fn with
+// each in the order of links as soon as it and the ones before it are done.
+// Works that fail are skipped and their errors returned as ScrapeErrors. An
+// error from fn stops the workers and is returned, unless it's StopCrawl.
+func (c *Client) walkWorks(ctx context.Context, f Fetcher, links []string, fn WorkFunc) error {
+	return c.walk(ctx, len(links), func(ctx context.Context, i int) (Work, error) {
+		return c.GetWork(ctx, f, links[i])
+	}, fn)
+}
+
+// walk is walkWorks for n works got by get.
+func (c *Client) walk(ctx context.Context, n int, get func(context.Context, int) (Work, error), fn WorkFunc) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		work Work
+		err  error
+		done chan struct{}
+	}
+	results := make([]result, n)
+	for i := range results {
+		results[i].done = make(chan struct{})
+	}
+
+	workers := c.opts.Workers
+	if workers > n {
+		workers = n
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				results[j].work, results[j].err = get(ctx, j)
+				close(results[j].done)
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for i := 0; i < n; i++ {
+			select {
+			case jobs <- i:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var errs ScrapeErrors
+	err := func() error {
+		for i := range results {
+			select {
+			case <-results[i].done:
+			case <-ctx.Done():
+				errs = append(errs, ctx.Err())
+				return nil
+			}
+			if err := results[i].err; err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if err := fn(results[i].work); err != nil {
+				return err
+			}
+		}
+		return nil
+	}()
+	cancel()
+	wg.Wait()
+
+	switch {
+	case err == StopCrawl:
+		err = nil
+	case err != nil:
+		return err
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
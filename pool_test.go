@@ -0,0 +1,139 @@
+package ao3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWalkWorks(t *testing.T) {
+	var running, most int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&running, 1)
+		defer atomic.AddInt32(&running, -1)
+		for {
+			m := atomic.LoadInt32(&most)
+			if n <= m || atomic.CompareAndSwapInt32(&most, m, n) {
+				break
+			}
+		}
+
+		// finish out of order
+		if strings.HasSuffix(r.URL.Path, "/1") {
+			time.Sleep(50 * time.Millisecond)
+		}
+		if strings.HasSuffix(r.URL.Path, "/404") {
+			http.NotFound(w, r)
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+		http.ServeFile(w, r, "testdata/work.html")
+	}))
+	defer srv.Close()
+
+	opts := DefaultOptions()
+	opts.Workers = 3
+	opts.RateLimit = 60000
+	opts.Jitter = 0
+	c := NewClient(opts)
+
+	f := NewHTTPFetcher()
+	defer f.Close()
+
+	links := []string{
+		srv.URL + "/works/1",
+		srv.URL + "/works/2",
+		srv.URL + "/works/404",
+		srv.URL + "/works/3",
+	}
+	var works []Work
+	err := c.walkWorks(context.Background(), f, links, func(w Work) error {
+		works = append(works, w)
+		return nil
+	})
+
+	var errs ScrapeErrors
+	if !errors.As(err, &errs) || len(errs) != 1 || !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got error %v, expected one ErrNotFound", err)
+	}
+	var e *Error
+	if !errors.As(errs[0], &e) || !strings.Contains(e.URL, "/works/404") {
+		t.Errorf("got error for %v, expected /works/404", errs[0])
+	}
+
+	var ids []int
+	for _, w := range works {
+		ids = append(ids, w.WorkID)
+	}
+	if len(ids) != 3 || ids[0] != 1 || ids[1] != 2 || ids[2] != 3 {
+		t.Errorf("got works %v, expected [1 2 3]", ids)
+	}
+	if most < 2 || most > 3 {
+		t.Errorf("got %d requests at once, expected 2 or 3", most)
+	}
+}
+
+func TestWalkWorksCanceled(t *testing.T) {
+	c := NewClient(DefaultOptions())
+	f := NewHTTPFetcher()
+	defer f.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	var works []Work
+	err := c.walkWorks(ctx, f, []string{testWork, testWork}, func(w Work) error {
+		works = append(works, w)
+		return nil
+	})
+	if len(works) != 0 || !errors.Is(err, context.Canceled) {
+		t.Errorf("got %d works and error %v, expected context.Canceled", len(works), err)
+	}
+}
+
+func TestWalkWorksStop(t *testing.T) {
+	var requests int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		http.ServeFile(w, r, "testdata/work.html")
+	}))
+	defer srv.Close()
+
+	opts := DefaultOptions()
+	opts.Workers = 1
+	opts.RateLimit = 60000
+	opts.Jitter = 0
+	c := NewClient(opts)
+
+	f := NewHTTPFetcher()
+	defer f.Close()
+
+	var links []string
+	for i := 1; i <= 10; i++ {
+		links = append(links, fmt.Sprintf("%s/works/%d", srv.URL, i))
+	}
+
+	var ids []int
+	err := c.walkWorks(context.Background(), f, links, func(w Work) error {
+		ids = append(ids, w.WorkID)
+		if len(ids) == 2 {
+			return StopCrawl
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 2 || ids[0] != 1 || ids[1] != 2 {
+		t.Errorf("got works %v, expected [1 2]", ids)
+	}
+	if n := atomic.LoadInt32(&requests); n > 4 {
+		t.Errorf("got %d requests after stopping, expected the crawl to stop", n)
+	}
+}
@@ -1,8 +1,7 @@
 package ao3
 
 import (
-	"errors"
-	"log"
+	"fmt"
 	"net/url"
 	"path"
 	"strings"
@@ -16,6 +15,7 @@ type Query struct {
 	IsAudio bool
 	Query   url.Values
 	path    []string
+	err     error
 	*url.URL
 	*Host
 }
@@ -30,41 +30,54 @@ type Path struct {
 	paths []string
 }
 
-func New(uri ...string) *Query {
+func New(uri ...string) (*Query, error) {
 	if len(uri) > 0 {
 		return ParseURL(uri[0])
 	}
-	return &Query{
+	q := &Query{
 		URL: &url.URL{
 			Scheme: "https",
 		},
 		Query: make(url.Values),
 		Host:  &Host{},
 	}
+	return q, nil
 }
 
-func ParseURL(uri string) *Query {
+func ParseURL(uri string) (*Query, error) {
 	q := &Query{}
 	u, err := url.Parse(uri)
 	if err != nil {
-		log.Fatal(err)
+		return nil, newError(uri, "", fmt.Errorf("%w: %w", ErrParse, err))
 	}
 	q.URL = u
 	q.Query = u.Query()
 	q.AppendPath(strings.Split(q.URL.Path, "/")...)
-	q.SetHost(q.URL.Host)
-	return q
+	q.Host = &Host{}
+	if u.Host != "" {
+		if err := q.SetHost(u.Host).Err(); err != nil {
+			return nil, newError(uri, "", err)
+		}
+	}
+	return q, nil
 }
 
+// SetHost sets the query's host. A host that can't be parsed is kept as far
+// as it could be, and its error returned by Err.
 func (q *Query) SetHost(host string) *Query {
 	h, err := ParseHost(host)
-	if err != nil {
-		log.Printf("%v\n", err)
+	if err != nil && q.err == nil {
+		q.err = err
 	}
 	q.Host = h
 	return q
 }
 
+// Err returns the first error from building the query.
+func (q *Query) Err() error {
+	return q.err
+}
+
 func (q *Query) AppendPath(paths ...string) *Query {
 	q.path = append(q.path, paths...)
 	return q
@@ -106,7 +119,7 @@ func ParseHost(hs string) (*Host, error) {
 		host.TLD(h[1])
 		return host, nil
 	default:
-		return host, errors.New("invalid host")
+		return host, fmt.Errorf("%w: invalid host %q", ErrParse, hs)
 	}
 }
 
@@ -0,0 +1,30 @@
+package ao3
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestQueryHost(t *testing.T) {
+	q, err := New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := q.SetHost(ao3Host).Err(); err != nil {
+		t.Errorf("got error %v for %s", err, ao3Host)
+	}
+	if err := q.SetHost("localhost").Err(); !errors.Is(err, ErrParse) {
+		t.Errorf("got error %v, expected ErrParse", err)
+	}
+	if err := q.SetHost("a.b.c.d").Err(); err == nil || !strings.Contains(err.Error(), "localhost") {
+		t.Errorf("got error %v, expected the first bad host's", err)
+	}
+
+	if _, err := ParseURL("https://localhost/works/1"); !errors.Is(err, ErrParse) {
+		t.Errorf("got error %v, expected ErrParse", err)
+	}
+	if _, err := ParseURL("/works/1"); err != nil {
+		t.Errorf("got error %v for a url without a host", err)
+	}
+}
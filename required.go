@@ -0,0 +1,225 @@
+package ao3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rating is one of ao3's ratings, valued by its ao3 tag id.
+type Rating int
+
+const (
+	RatingNotRated Rating = 9
+	RatingGeneral  Rating = 10
+	RatingTeen     Rating = 11
+	RatingMature   Rating = 12
+	RatingExplicit Rating = 13
+)
+
+// ArchiveWarning is one of ao3's archive warnings, valued by its ao3 tag id.
+type ArchiveWarning int
+
+const (
+	WarningChoseNotToUse       ArchiveWarning = 14
+	WarningNone                ArchiveWarning = 16
+	WarningViolence            ArchiveWarning = 17
+	WarningMajorCharacterDeath ArchiveWarning = 18
+	WarningNonCon              ArchiveWarning = 19
+	WarningUnderage            ArchiveWarning = 20
+)
+
+// Category is one of ao3's relationship categories, valued by its ao3 tag
+// id.
+type Category int
+
+const (
+	CategoryGen   Category = 21
+	CategoryFM    Category = 22
+	CategoryMM    Category = 23
+	CategoryOther Category = 24
+	CategoryFF    Category = 116
+	CategoryMulti Category = 2246
+)
+
+// requiredTag describes a rating, warning or category: its name on ao3, the
+// class of its icon in work blurbs, and the other names it's known by.
+type requiredTag struct {
+	name    string
+	icon    string
+	aliases []string
+}
+
+var ratings = map[Rating]requiredTag{
+	RatingNotRated: {"Not Rated", "rating-notrated", []string{"none", "unrated"}},
+	RatingGeneral:  {"General Audiences", "rating-general-audience", []string{"general", "gen"}},
+	RatingTeen:     {"Teen And Up Audiences", "rating-teen", []string{"teen", "t"}},
+	RatingMature:   {"Mature", "rating-mature", []string{"m"}},
+	RatingExplicit: {"Explicit", "rating-explicit", []string{"e"}},
+}
+
+var warnings = map[ArchiveWarning]requiredTag{
+	WarningChoseNotToUse:       {"Creator Chose Not To Use Archive Warnings", "warning-choosenotto", []string{"Choose Not To Use Archive Warnings", "chose not to use", "choose not to use"}},
+	WarningNone:                {"No Archive Warnings Apply", "warning-no", []string{"none", "no warnings"}},
+	WarningViolence:            {"Graphic Depictions Of Violence", "warning-yes", []string{"violence"}},
+	WarningMajorCharacterDeath: {"Major Character Death", "warning-yes", []string{"mcd"}},
+	WarningNonCon:              {"Rape/Non-Con", "warning-yes", []string{"non-con", "noncon", "rape"}},
+	WarningUnderage:            {"Underage Sex", "warning-yes", []string{"Underage"}},
+}
+
+var categories = map[Category]requiredTag{
+	CategoryGen:   {"Gen", "category-gen", nil},
+	CategoryFM:    {"F/M", "category-het", []string{"fm", "het"}},
+	CategoryMM:    {"M/M", "category-slash", []string{"mm", "slash"}},
+	CategoryOther: {"Other", "category-other", nil},
+	CategoryFF:    {"F/F", "category-femslash", []string{"ff", "femslash"}},
+	CategoryMulti: {"Multi", "category-multi", nil},
+}
+
+// ID returns the rating's ao3 tag id, used by search filters.
+func (r Rating) ID() int {
+	return int(r)
+}
+
+// String returns the rating's name on ao3.
+func (r Rating) String() string {
+	return ratings[r].name
+}
+
+// Icon returns the class of the rating's icon in work blurbs.
+func (r Rating) Icon() string {
+	return ratings[r].icon
+}
+
+func (w ArchiveWarning) ID() int {
+	return int(w)
+}
+
+func (w ArchiveWarning) String() string {
+	return warnings[w].name
+}
+
+func (w ArchiveWarning) Icon() string {
+	return warnings[w].icon
+}
+
+func (c Category) ID() int {
+	return int(c)
+}
+
+func (c Category) String() string {
+	return categories[c].name
+}
+
+func (c Category) Icon() string {
+	return categories[c].icon
+}
+
+// ParseRating reads a rating from its name, a short name like explicit or
+// teen, or its ao3 id.
+func ParseRating(s string) (Rating, error) {
+	r, err := lookupRequired(ratings, s)
+	if err != nil {
+		return 0, fmt.Errorf("rating %w", err)
+	}
+	return r, nil
+}
+
+// ParseArchiveWarning reads an archive warning from its name, a short name
+// like mcd, or its ao3 id.
+func ParseArchiveWarning(s string) (ArchiveWarning, error) {
+	w, err := lookupRequired(warnings, s)
+	if err != nil {
+		return 0, fmt.Errorf("archive warning %w", err)
+	}
+	return w, nil
+}
+
+// ParseCategory reads a category from its name, a short name like mm, or its
+// ao3 id.
+func ParseCategory(s string) (Category, error) {
+	c, err := lookupRequired(categories, s)
+	if err != nil {
+		return 0, fmt.Errorf("category %w", err)
+	}
+	return c, nil
+}
+
+func (r Rating) MarshalText() ([]byte, error) {
+	return []byte(r.String()), nil
+}
+
+func (r *Rating) UnmarshalText(b []byte) error {
+	v, err := ParseRating(string(b))
+	*r = v
+	return err
+}
+
+func (w ArchiveWarning) MarshalText() ([]byte, error) {
+	return []byte(w.String()), nil
+}
+
+func (w *ArchiveWarning) UnmarshalText(b []byte) error {
+	v, err := ParseArchiveWarning(string(b))
+	*w = v
+	return err
+}
+
+func (c Category) MarshalText() ([]byte, error) {
+	return []byte(c.String()), nil
+}
+
+func (c *Category) UnmarshalText(b []byte) error {
+	v, err := ParseCategory(string(b))
+	*c = v
+	return err
+}
+
+func lookupRequired[T ~int](tags map[T]requiredTag, s string) (T, error) {
+	s = strings.TrimSpace(s)
+	if id, err := strconv.Atoi(s); err == nil {
+		if _, ok := tags[T(id)]; ok {
+			return T(id), nil
+		}
+	}
+	for t, tag := range tags {
+		if strings.EqualFold(s, tag.name) {
+			return t, nil
+		}
+		for _, a := range tag.aliases {
+			if strings.EqualFold(s, a) {
+				return t, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown %q", ErrParse, s)
+}
+
+// parseRequired reads the names of a work's ratings, warnings or categories,
+// leaving out any it doesn't know.
+func parseRequired[T ~int](tags map[T]requiredTag, names []string) []T {
+	var vals []T
+	for _, n := range names {
+		if t, err := lookupRequired(tags, n); err == nil {
+			vals = append(vals, t)
+		}
+	}
+	return vals
+}
+
+// requiredNames returns the names of ratings, warnings or categories.
+func requiredNames[T fmt.Stringer](vals []T) []string {
+	names := make([]string, len(vals))
+	for i, v := range vals {
+		names[i] = v.String()
+	}
+	return names
+}
+
+func firstOf[T any](vals []T) T {
+	var v T
+	if len(vals) > 0 {
+		v = vals[0]
+	}
+	return v
+}
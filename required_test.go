@@ -0,0 +1,46 @@
+package ao3
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseRequired(t *testing.T) {
+	for in, want := range map[string]Rating{
+		"explicit":              RatingExplicit,
+		"Teen And Up Audiences": RatingTeen,
+		"10":                    RatingGeneral,
+	} {
+		r, err := ParseRating(in)
+		if err != nil || r != want {
+			t.Errorf("%q: got %v %v, expected %v", in, r, err, want)
+		}
+	}
+	if _, err := ParseRating("spicy"); err == nil {
+		t.Error("parsed an unknown rating")
+	}
+
+	w, err := ParseArchiveWarning("major character death")
+	if err != nil || w != WarningMajorCharacterDeath || w.ID() != 18 || w.Icon() != "warning-yes" {
+		t.Errorf("got warning %v %d %s %v", w, w.ID(), w.Icon(), err)
+	}
+	if w, _ := ParseArchiveWarning("Choose Not To Use Archive Warnings"); w != WarningChoseNotToUse {
+		t.Errorf("got warning %v for the blurb icon title", w)
+	}
+
+	c, err := ParseCategory("F/M")
+	if err != nil || c.ID() != 22 || c.Icon() != "category-het" {
+		t.Errorf("got category %v %d %s %v", c, c.ID(), c.Icon(), err)
+	}
+
+	b, err := json.Marshal(struct {
+		Rating   Rating
+		Warnings []ArchiveWarning
+	}{RatingMature, []ArchiveWarning{WarningNone}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"Rating":"Mature","Warnings":["No Archive Warnings Apply"]}`; string(b) != want {
+		t.Errorf("got %s, expected %s", b, want)
+	}
+}
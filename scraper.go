@@ -3,43 +3,70 @@ package ao3
 import (
 	"context"
 	"fmt"
-	"log"
 	"net/http"
 	"net/url"
 	"os"
 	"path"
-	"strings"
-	"time"
 
 	cookiemonster "github.com/MercuryEngineering/CookieMonster"
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/cdproto/network"
 	"github.com/chromedp/chromedp"
-	"github.com/ohzqq/cdb"
 	"github.com/spf13/cast"
-	"github.com/spf13/viper"
 )
 
 const (
 	userAgent string = `user-agent=churkeybot/1.0 (+https://archiveofourown.org/users/churkey/profile)`
-	delay     int64  = 5000000000
 	ao3Host   string = `archiveofourown.org`
 )
 
-func Scrape(u string) ([]cdb.Book, error) {
-	var works []cdb.Book
+// Scrape scrapes the work at u with the DefaultClient.
+func Scrape(u string) ([]Work, error) {
+	return DefaultClient().ScrapeContext(context.Background(), u)
+}
+
+// ScrapeContext is Scrape, stopping when ctx is done.
+func ScrapeContext(ctx context.Context, u string) ([]Work, error) {
+	return DefaultClient().ScrapeContext(ctx, u)
+}
+
+// Page scrapes every work linked from the listing at u with the
+// DefaultClient.
+func Page(u string) ([]Work, error) {
+	return DefaultClient().PageContext(context.Background(), u)
+}
+
+// PageContext is Page, returning the works scraped so far when ctx is done.
+func PageContext(ctx context.Context, u string) ([]Work, error) {
+	return DefaultClient().PageContext(ctx, u)
+}
+
+// GetWork scrapes the work at u through f with the DefaultClient.
+func GetWork(ctx context.Context, f Fetcher, u string) (Work, error) {
+	return DefaultClient().GetWork(ctx, f, u)
+}
+
+// GetLinkList returns the work links of the listing at u, fetched through f
+// with the DefaultClient.
+func GetLinkList(ctx context.Context, f Fetcher, u string) ([]string, error) {
+	return DefaultClient().GetLinkList(ctx, f, u)
+}
 
-	ctx, cancel := chromedp.NewContext(context.Background())
-	defer cancel()
+func (c *Client) Scrape(u string) ([]Work, error) {
+	return c.ScrapeContext(context.Background(), u)
+}
+
+// ScrapeContext scrapes the work at u, stopping when ctx is done.
+func (c *Client) ScrapeContext(ctx context.Context, u string) ([]Work, error) {
+	var works []Work
 
-	err := chromedp.Run(ctx,
-		setCookies(u),
-	)
+	f, err := c.NewFetcher()
 	if err != nil {
 		return works, err
 	}
+	defer f.Close()
 
-	work, err := GetWork(ctx, u)
+	work, err := c.GetWork(ctx, f, u)
 	if err != nil {
 		return works, err
 	}
@@ -48,122 +75,78 @@ func Scrape(u string) ([]cdb.Book, error) {
 	return works, nil
 }
 
-func Page(u string) ([]cdb.Book, error) {
-	var works []cdb.Book
-
-	ctx, cancel := chromedp.NewContext(context.Background())
-	defer cancel()
+func (c *Client) Page(u string) ([]Work, error) {
+	return c.PageContext(context.Background(), u)
+}
 
-	err := chromedp.Run(ctx,
-		setCookies(u),
-	)
+// PageContext scrapes every work linked from the listing at u, returning the
+// works scraped so far when ctx is done. Works that fail don't stop the
+// others; their errors are returned together as ScrapeErrors.
+func (c *Client) PageContext(ctx context.Context, u string) ([]Work, error) {
+	f, err := c.NewFetcher()
 	if err != nil {
-		return works, err
+		return []Work{}, err
 	}
+	defer f.Close()
 
-	links := GetLinkList(ctx, u)
-	for _, link := range links {
-		work, err := GetWork(ctx, link)
-		if err != nil {
-			return works, err
-		}
-		works = append(works, work)
-	}
-
-	return works, nil
+	return c.scrapePage(ctx, f, u)
 }
 
-func GetWork(ctx context.Context, u string) (cdb.Book, error) {
-	viper.Set("url", u)
-
-	var (
-		work     cdb.Book
-		pubdate  string
-		title    string
-		comments string
-		formats  []*cdp.Node
-		tags     []*cdp.Node
-		fandom   []*cdp.Node
-		ships    []*cdp.Node
-		con      []*cdp.Node
-		rel      []*cdp.Node
-	)
-
-	actions := []chromedp.Action{
-		Sleep(5 * time.Second),
-		chromedp.Navigate(u),
-		GetInnerHTML(Comments, &comments),
-		GetString(Title, &title),
-		GetString(Pubdate, &pubdate),
-		GetAllNodes(Downloads, &formats),
-		GetNodes(Tags, &tags),
-		GetNodes(Ships, &ships),
-		GetNodes(Fandom, &fandom),
-		GetNodes(Author, &con),
-	}
-
-	if IsPodfic() {
-		actions = append(actions, GetAllNodes(RelatedWorks, &rel))
-	}
-
-	err := chromedp.Run(ctx, actions...)
+// scrapePage scrapes the works linked from the listing at u. When some of
+// them fail, the rest are returned along with ScrapeErrors.
+func (c *Client) scrapePage(ctx context.Context, f Fetcher, u string) ([]Work, error) {
+	var works []Work
+
+	doc, err := c.fetchPage(ctx, f, u)
 	if err != nil {
-		return work, err
+		return works, err
 	}
+	_, err = c.walkPage(ctx, f, u, doc, 0, func(w Work) error {
+		works = append(works, w)
+		return nil
+	})
+	return works, err
+}
 
-	work.Title = strings.TrimSpace(title)
-	work.Comments = strings.ReplaceAll(comments, "\n", "")
-	work.Pubdate = parsePubdate(pubdate)
-	work.Formats = parseFormats(formats)
-	work.Tags = parseTags(tags, ships, fandom)
+func (c *Client) GetWork(ctx context.Context, f Fetcher, u string) (Work, error) {
+	var work Work
 
-	var auth []string
-	if len(con) > 0 {
-		auth = append(auth, getFirstChildValues(con)...)
-	}
-	if IsPodfic() {
-		work.Narrators = auth
-	} else {
-		work.Authors = auth
+	pu, err := ParseUrl(u)
+	if err != nil {
+		return work, err
 	}
-	if len(rel) > 0 {
-		work.Authors = parseRelated(rel)
+
+	doc, err := c.fetchPage(ctx, f, pu.String())
+	if err != nil {
+		return work, err
 	}
 
-	getSeries(ctx, &work)
+	work, err = parseWork(doc, c.opts.Podfic)
+	if err != nil {
+		return work, withURL(err, u)
+	}
+	work.setID(ParseWorkID(u))
 
 	return work, nil
 }
 
-func GetLinkList(ctx context.Context, u string) []string {
-	var nodes []*cdp.Node
-	err := chromedp.Run(ctx,
-		Sleep(1*time.Second),
-		chromedp.Navigate(u),
-		chromedp.Nodes(
-			ListLink,
-			&nodes,
-			chromedp.ByQueryAll,
-			chromedp.NodeReady,
-		),
-	)
+func (c *Client) GetLinkList(ctx context.Context, f Fetcher, u string) ([]string, error) {
+	doc, err := c.fetchPage(ctx, f, u)
 	if err != nil {
-		fmt.Errorf("%w %w\n", scrapeErr("link list"), err)
-		return []string{}
+		return []string{}, err
 	}
 
-	var links []string
-	for _, node := range nodes {
-		t := ParseUrl(node.AttributeValue("href"))
-		links = append(links, t.String())
+	links, err := parseLinkList(doc)
+	if err != nil {
+		return links, withURL(err, u)
 	}
-	return links
+	return links, nil
 }
 
-func ParseUrl(u string) *url.URL {
+func ParseUrl(u string) (*url.URL, error) {
 	pu, err := url.Parse(u)
 	if err != nil {
-		log.Fatal(err)
+		return nil, newError(u, "", fmt.Errorf("%w: %w", ErrParse, err))
 	}
 
 	vals := pu.Query()
@@ -180,13 +163,28 @@ func ParseUrl(u string) *url.URL {
 		pu.Host = ao3Host
 	}
 
-	return pu
+	return pu, nil
+}
+
+// absoluteURL resolves a link from an ao3 page against the ao3 host.
+func absoluteURL(href string) string {
+	u, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	if u.Scheme == "" {
+		u.Scheme = "https"
+	}
+	if u.Host == "" {
+		u.Host = ao3Host
+	}
+	return u.String()
 }
 
-func setCookies(u string) chromedp.ActionFunc {
+func setCookies(u string, cookies []*http.Cookie) chromedp.ActionFunc {
 	return func(ctx context.Context) error {
-		cparams := make([]*network.CookieParam, len(Cookies()))
-		for i, c := range Cookies() {
+		cparams := make([]*network.CookieParam, len(cookies))
+		for i, c := range cookies {
 			t := cdp.TimeSinceEpoch(c.Expires)
 			cparams[i] = &network.CookieParam{
 				Name:     c.Name,
@@ -210,13 +208,17 @@ func setCookies(u string) chromedp.ActionFunc {
 	}
 }
 
-func Cookies() []*http.Cookie {
-	cfg, _ := os.UserConfigDir()
+// Cookies reads the ao3 login cookies from the user's config dir.
+func Cookies() ([]*http.Cookie, error) {
+	cfg, err := os.UserConfigDir()
+	if err != nil {
+		return nil, err
+	}
 	file := path.Join(cfg, "ur", "cookies.txt")
 
 	cookies, err := cookiemonster.ParseFile(file)
 	if err != nil {
-		log.Fatal(err)
+		return nil, fmt.Errorf("reading cookies: %w", err)
 	}
-	return cookies
+	return cookies, nil
 }
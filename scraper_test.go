@@ -7,7 +7,6 @@ import (
 
 	"github.com/danielgtaylor/casing"
 	"github.com/ohzqq/cdb"
-	"github.com/spf13/viper"
 )
 
 const (
@@ -51,8 +50,9 @@ func TestPage(t *testing.T) {
 }
 
 func TestPodfic(t *testing.T) {
-	viper.Set("podfic", true)
-	books, err := Scrape(testPodfic)
+	opts := DefaultOptions()
+	opts.Podfic = true
+	books, err := NewClient(opts).Scrape(testPodfic)
 	if err != nil {
 		t.Error(err)
 	}
@@ -97,14 +97,13 @@ func TestWorkCmd(t *testing.T) {
 		t.Fatal(err)
 		return
 	}
-	w := viper.GetString("url")
-	println(w)
-
 	for _, book := range books {
 		if book.Title == "" {
 			t.Fatal("no title")
 		}
-		err := book.Save(casing.Snake(book.Title)+".yaml", true)
+		err := cdb.NewSerializer(&book.Book).
+			Encoder(cdb.EncodeYAML).
+			WriteFile(casing.Snake(book.Title))
 		if err != nil {
 			t.Error(err)
 		}
@@ -112,17 +111,15 @@ func TestWorkCmd(t *testing.T) {
 }
 
 func TestReadMeta(t *testing.T) {
-	files, err := filepath.Glob("testdata/*")
+	files, err := filepath.Glob("testdata/*.yaml")
 	if err != nil {
 		t.Error(err)
 	}
 	for _, file := range files {
-		dec, err := cdb.ReadMetadataFile(file)
-		if err != nil {
-			t.Error(err)
-		}
 		var book cdb.Book
-		err = dec.Decode(&book)
+		err := cdb.NewSerializer(&book).
+			Decoder(cdb.DecodeYAML).
+			ReadFile(file)
 		if err != nil {
 			t.Error(err)
 		}
@@ -2,97 +2,135 @@ package ao3
 
 import (
 	"context"
-	"log"
 	"net/url"
 	"strconv"
-	"time"
+	"strings"
 
-	"github.com/chromedp/cdproto/cdp"
-	"github.com/chromedp/chromedp"
-	"github.com/ohzqq/cdb"
-	"github.com/spf13/cast"
+	"github.com/PuerkitoBio/goquery"
 )
 
-func Search(u string) ([]cdb.Book, error) {
-	sUrl := ParseUrl(u)
-	params := sUrl.Query()
-	for _, k := range SearchParams() {
-		if params.Has(k) && params.Get(k) == "" {
-			params.Del(k)
-		}
-	}
-	sUrl.RawQuery = params.Encode()
+const Pagination = `ol.pagination li`
 
-	return parseList(sUrl)
+// Search scrapes every work in the search results at u with the
+// DefaultClient.
+func Search(u string) ([]Work, error) {
+	return DefaultClient().SearchContext(context.Background(), u)
 }
 
-func SortAndFilter(u string) ([]cdb.Book, error) {
-	sUrl := ParseUrl(u)
-	params := sUrl.Query()
-	for _, k := range SortAndFilterParams() {
-		if params.Has(k) && params.Get(k) == "" {
-			params.Del(k)
-		}
-	}
-	sUrl.RawQuery = params.Encode()
+// SearchContext is Search, returning the works scraped so far when ctx is
+// done.
+func SearchContext(ctx context.Context, u string) ([]Work, error) {
+	return DefaultClient().SearchContext(ctx, u)
+}
+
+// SortAndFilter scrapes every work in the filtered tag listing at u with the
+// DefaultClient.
+func SortAndFilter(u string) ([]Work, error) {
+	return DefaultClient().SortAndFilterContext(context.Background(), u)
+}
 
-	return parseList(sUrl)
+// SortAndFilterContext is SortAndFilter, returning the works scraped so far
+// when ctx is done.
+func SortAndFilterContext(ctx context.Context, u string) ([]Work, error) {
+	return DefaultClient().SortAndFilterContext(ctx, u)
 }
 
-func parseList(u *url.URL) ([]cdb.Book, error) {
-	var works []cdb.Book
+// SearchFunc calls fn with every work in the search results at u, with the
+// DefaultClient.
+func SearchFunc(ctx context.Context, u string, fn WorkFunc) error {
+	return DefaultClient().SearchFunc(ctx, u, fn)
+}
+
+// SortAndFilterFunc calls fn with every work in the filtered tag listing at
+// u, with the DefaultClient.
+func SortAndFilterFunc(ctx context.Context, u string, fn WorkFunc) error {
+	return DefaultClient().SortAndFilterFunc(ctx, u, fn)
+}
+
+func (c *Client) Search(u string) ([]Work, error) {
+	return c.SearchContext(context.Background(), u)
+}
 
-	ctx, cancel := chromedp.NewContext(context.Background())
-	defer cancel()
+// SearchContext scrapes every work in the search results at u, returning the
+// works scraped so far when ctx is done.
+func (c *Client) SearchContext(ctx context.Context, u string) ([]Work, error) {
+	sUrl, err := searchURL(u, SearchParams())
+	if err != nil {
+		return []Work{}, err
+	}
+	return c.parseList(ctx, sUrl)
+}
 
-	err := chromedp.Run(ctx,
-		setCookies(u.String()),
-	)
+// SearchFunc calls fn with each work in the search results at u as soon as
+// it's scraped.
+func (c *Client) SearchFunc(ctx context.Context, u string, fn WorkFunc) error {
+	sUrl, err := searchURL(u, SearchParams())
 	if err != nil {
-		return works, err
+		return err
 	}
+	return c.walkList(ctx, sUrl, fn)
+}
 
-	total := getTotalPages(ctx, u.String())
+func (c *Client) SortAndFilter(u string) ([]Work, error) {
+	return c.SortAndFilterContext(context.Background(), u)
+}
 
-	params := u.Query()
-	for i := 1; i <= total; i++ {
-		page := strconv.Itoa(i)
-		params.Set("page", page)
-		u.RawQuery = params.Encode()
-		w, err := Page(u.String())
-		if err != nil {
-			return works, err
-		}
-		works = append(works, w...)
+// SortAndFilterContext scrapes every work in the filtered tag listing at u,
+// returning the works scraped so far when ctx is done.
+func (c *Client) SortAndFilterContext(ctx context.Context, u string) ([]Work, error) {
+	sUrl, err := searchURL(u, SortAndFilterParams())
+	if err != nil {
+		return []Work{}, err
 	}
+	return c.parseList(ctx, sUrl)
+}
 
-	return works, nil
-}
-
-func getTotalPages(ctx context.Context, u string) int {
-	var nodes []*cdp.Node
-	err := chromedp.Run(ctx,
-		Sleep(5*time.Second),
-		chromedp.Navigate(u),
-		chromedp.Nodes(
-			".pagination > li > a",
-			&nodes,
-			chromedp.ByQueryAll,
-			chromedp.NodeReady,
-			chromedp.AtLeast(0),
-		),
-	)
+// SortAndFilterFunc calls fn with each work in the filtered tag listing at u
+// as soon as it's scraped.
+func (c *Client) SortAndFilterFunc(ctx context.Context, u string, fn WorkFunc) error {
+	sUrl, err := searchURL(u, SortAndFilterParams())
 	if err != nil {
-		log.Println(err)
-		return 1
+		return err
 	}
+	return c.walkList(ctx, sUrl, fn)
+}
 
-	child := getFirstChildValues(nodes)
-	if len(child) > 0 {
-		return cast.ToInt(child[len(child)-2])
+// searchURL parses u, dropping the empty values of keys. Its language isn't
+// checked since ao3 may know languages that Languages doesn't.
+func searchURL(u string, keys []string) (*url.URL, error) {
+	sUrl, err := ParseUrl(u)
+	if err != nil {
+		return nil, err
 	}
+	params := sUrl.Query()
+	for _, k := range keys {
+		if params.Has(k) && params.Get(k) == "" {
+			params.Del(k)
+		}
+	}
+	sUrl.RawQuery = params.Encode()
+	return sUrl, nil
+}
 
-	return 1
+func (c *Client) parseList(ctx context.Context, u *url.URL) ([]Work, error) {
+	var works []Work
+	err := c.walkList(ctx, u, func(w Work) error {
+		works = append(works, w)
+		return nil
+	})
+	return works, err
+}
+
+// parseTotalPages reads the number of the last page from a listing's
+// pagination, which is 1 when there isn't any.
+func parseTotalPages(doc *goquery.Document) int {
+	total := 1
+	doc.Find(Pagination).Each(func(_ int, node *goquery.Selection) {
+		if n, err := strconv.Atoi(strings.TrimSpace(node.Text())); err == nil && n > total {
+			total = n
+		}
+	})
+	return total
 }
 
 func SearchParams() []string {
@@ -108,6 +146,8 @@ func SearchParams() []string {
 		searchLangId,
 		searchFandomNames,
 		searchRatingIDs,
+		searchWarningIDs,
+		searchCategoryIDs,
 		searchCharNames,
 		searchRelNames,
 		searchTags,
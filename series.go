@@ -0,0 +1,351 @@
+package ao3
+
+import (
+	"context"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	SeriesTitle = `#main h2.heading`
+	SeriesMeta  = `dl.series.meta > dt`
+	SeriesStats = `dl.stats > dt`
+
+	// seriesPerPage is how many works ao3 lists on each page of a series.
+	seriesPerPage = 20
+)
+
+// Series is a scraped series, with its member works in series order.
+type Series struct {
+	ID          int
+	Title       string
+	Creators    []string
+	Begun       time.Time
+	Updated     time.Time
+	Description string
+	Notes       string
+	Words       int
+	Works       int
+	Complete    bool
+	Bookmarks   int
+	Members     []Work
+}
+
+// SeriesPart is a work's place in a series.
+type SeriesPart struct {
+	ID       int    `yaml:"id,omitempty" toml:"id,omitempty" json:"id,omitempty"`
+	Name     string `yaml:"name" toml:"name" json:"name"`
+	URL      string `yaml:"url" toml:"url" json:"url"`
+	Position int    `yaml:"position" toml:"position" json:"position"`
+}
+
+// ScrapeSeries scrapes the series at u and its works with the
+// DefaultClient.
+func ScrapeSeries(u string) (Series, error) {
+	return DefaultClient().ScrapeSeriesContext(context.Background(), u)
+}
+
+// ScrapeSeriesContext is ScrapeSeries, returning the works scraped so far
+// when ctx is done.
+func ScrapeSeriesContext(ctx context.Context, u string) (Series, error) {
+	return DefaultClient().ScrapeSeriesContext(ctx, u)
+}
+
+// SeriesFunc scrapes the series at u, calling fn with each of its works,
+// with the DefaultClient.
+func SeriesFunc(ctx context.Context, u string, fn WorkFunc) (Series, error) {
+	return DefaultClient().SeriesFunc(ctx, u, fn)
+}
+
+func (c *Client) ScrapeSeries(u string) (Series, error) {
+	return c.ScrapeSeriesContext(context.Background(), u)
+}
+
+// ScrapeSeriesContext scrapes the series at u along with its works,
+// returning the works scraped so far when ctx is done. Works that fail are
+// left out of Members and their errors returned as ScrapeErrors.
+func (c *Client) ScrapeSeriesContext(ctx context.Context, u string) (Series, error) {
+	var members []Work
+	s, err := c.SeriesFunc(ctx, u, func(w Work) error {
+		members = append(members, w)
+		return nil
+	})
+	s.Members = members
+	return s, err
+}
+
+// SeriesFunc scrapes the series at u, calling fn with each of its works as
+// soon as it's scraped. The works' Series and SeriesIndex are set from the
+// series page, whatever their own pages say. The returned Series has no
+// Members.
+func (c *Client) SeriesFunc(ctx context.Context, u string, fn WorkFunc) (Series, error) {
+	var s Series
+
+	su, err := ParseUrl(u)
+	if err != nil {
+		return s, err
+	}
+
+	var (
+		positions = make(map[int]int)
+		listed    int
+	)
+	if c.opts.StartPage > 1 {
+		listed = (c.opts.StartPage - 1) * seriesPerPage
+	}
+	err = c.crawl(ctx, su, c.workPages(func(page int, doc *goquery.Document) {
+		if s.Title == "" {
+			s = parseSeries(doc)
+			s.ID = ParseSeriesID(su.String())
+		}
+		for _, id := range listedWorkIDs(doc) {
+			listed++
+			positions[id] = listed
+		}
+	}, func(w Work) error {
+		if pos, ok := positions[w.WorkID]; ok {
+			w.setPrimarySeries(SeriesPart{
+				ID:       s.ID,
+				Name:     s.Title,
+				URL:      s.URL(),
+				Position: pos,
+			})
+		}
+		return fn(w)
+	}))
+	return s, err
+}
+
+// ParseSeriesHTML reads a saved series page, building its members, in
+// series order, from their blurbs.
+func ParseSeriesHTML(r io.Reader) (Series, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return Series{}, err
+	}
+	if err := CheckPage(doc); err != nil {
+		return Series{}, err
+	}
+
+	s := parseSeries(doc)
+	s.ID = seriesPageID(doc, s.Title)
+	for i, w := range parseBlurbs(doc, false) {
+		w.setPrimarySeries(SeriesPart{
+			ID:       s.ID,
+			Name:     s.Title,
+			URL:      s.URL(),
+			Position: i + 1,
+		})
+		s.Members = append(s.Members, w)
+	}
+	return s, nil
+}
+
+// seriesPageID reads the id of the series on a series page from the page's
+// url, or else from the link back to the series in its members' blurbs.
+func seriesPageID(doc *goquery.Document, title string) int {
+	if doc.Url != nil {
+		if id := ParseSeriesID(doc.Url.String()); id != 0 {
+			return id
+		}
+	}
+	var id int
+	doc.Find(Blurb).Find(BlurbSeries + ` a[href^="/series/"]`).EachWithBreak(func(_ int, a *goquery.Selection) bool {
+		if strings.TrimSpace(a.Text()) == title {
+			id = ParseSeriesID(a.AttrOr("href", ""))
+		}
+		return id == 0
+	})
+	return id
+}
+
+// URL returns the series' canonical url.
+func (s Series) URL() string {
+	if s.ID == 0 {
+		return ""
+	}
+	return SeriesURL(s.ID)
+}
+
+// StringMap converts a series to map[string]any, listing its members by
+// position.
+func (s Series) StringMap() map[string]any {
+	m := map[string]any{
+		"title":    s.Title,
+		"complete": s.Complete,
+	}
+	if s.ID != 0 {
+		m["series_id"] = s.ID
+		m["url"] = s.URL()
+	}
+	if v := s.Creators; len(v) != 0 {
+		m["authors"] = v
+	}
+	if v := s.Begun; !v.IsZero() {
+		m["begun"] = v
+	}
+	if v := s.Updated; !v.IsZero() {
+		m["updated"] = v
+	}
+	if v := s.Description; v != "" {
+		m["description"] = v
+	}
+	if v := s.Notes; v != "" {
+		m["notes"] = v
+	}
+	if v := s.Words; v != 0 {
+		m["words"] = v
+	}
+	if v := s.Works; v != 0 {
+		m["work_count"] = v
+	}
+	if v := s.Bookmarks; v != 0 {
+		m["bookmarks"] = v
+	}
+	if len(s.Members) != 0 {
+		members := make([]map[string]any, len(s.Members))
+		for i, w := range s.Members {
+			members[i] = map[string]any{
+				"position": w.SeriesIndex,
+				"title":    w.Title,
+				"url":      w.CanonicalURL(),
+				"authors":  w.Authors,
+			}
+		}
+		m["works"] = members
+	}
+	return m
+}
+
+func parseSeries(doc *goquery.Document) Series {
+	s := Series{
+		Title: strings.TrimSpace(doc.Find(SeriesTitle).First().Text()),
+	}
+
+	doc.Find(SeriesMeta).Each(func(_ int, dt *goquery.Selection) {
+		dd := dt.NextFiltered("dd")
+		switch strings.TrimSuffix(strings.TrimSpace(dt.Text()), ":") {
+		case "Creator", "Creators":
+			s.Creators = getTextValues(dd.Find("a"))
+		case "Series Begun":
+			s.Begun = parseSeriesDate(dd.Text())
+		case "Series Updated":
+			s.Updated = parseSeriesDate(dd.Text())
+		case "Description":
+			s.Description = userstuff(dd)
+		case "Notes":
+			s.Notes = userstuff(dd)
+		case "Stats":
+			dd.Find(SeriesStats).Each(func(_ int, dt *goquery.Selection) {
+				v := dt.NextFiltered("dd").Text()
+				switch strings.TrimSuffix(strings.TrimSpace(dt.Text()), ":") {
+				case "Words":
+					s.Words = parseCount(v)
+				case "Works":
+					s.Works = parseCount(v)
+				case "Complete":
+					s.Complete = strings.TrimSpace(v) == "Yes"
+				case "Bookmarks":
+					s.Bookmarks = parseCount(v)
+				}
+			})
+		}
+	})
+
+	return s
+}
+
+// parseSeriesParts reads the "Part 2 of Series" of each series in sel.
+func parseSeriesParts(sel *goquery.Selection) []SeriesPart {
+	var parts []SeriesPart
+	sel.Each(func(_ int, node *goquery.Selection) {
+		text := strings.Join(strings.Fields(node.Text()), " ")
+		_, pos, err := parseSeriesText(text)
+		if err != nil {
+			return
+		}
+		link := node.Find(`a[href*="/series/"]`).First()
+		part := SeriesPart{
+			ID:       ParseSeriesID(link.AttrOr("href", "")),
+			Name:     strings.TrimSpace(link.Text()),
+			Position: int(pos),
+		}
+		if part.ID != 0 {
+			part.URL = SeriesURL(part.ID)
+		}
+		parts = append(parts, part)
+	})
+	return parts
+}
+
+// setSeries records the work's series, the first being its book series.
+func (w *Work) setSeries(parts []SeriesPart) {
+	w.SeriesParts = parts
+	if len(parts) > 0 {
+		w.Series = parts[0].Name
+		w.SeriesIndex = float64(parts[0].Position)
+	}
+}
+
+// setPrimarySeries makes p the work's book series, moving it to the front
+// of its series parts.
+func (w *Work) setPrimarySeries(p SeriesPart) {
+	parts := []SeriesPart{p}
+	for _, o := range w.SeriesParts {
+		same := o.ID == p.ID
+		if p.ID == 0 {
+			same = o.Name == p.Name
+		}
+		if !same {
+			parts = append(parts, o)
+		}
+	}
+	w.setSeries(parts)
+}
+
+// listedWorkIDs returns the ids of the works listed on doc, in order.
+func listedWorkIDs(doc *goquery.Document) []int {
+	links, _ := parseLinkList(doc)
+	ids := make([]int, 0, len(links))
+	for _, l := range links {
+		id, _ := ParseWorkID(l)
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func userstuff(sel *goquery.Selection) string {
+	h, _ := sel.Find(".userstuff").First().Html()
+	return strings.TrimSpace(h)
+}
+
+// parseSeriesDate reads a date on a series page, like 2014-11-02.
+func parseSeriesDate(s string) time.Time {
+	t, _ := time.Parse("2006-01-02", strings.TrimSpace(s))
+	return t
+}
+
+// parseSeriesBlurb builds a series from its blurb in a listing, without its
+// notes, begun date or members.
+func parseSeriesBlurb(sel *goquery.Selection) (Series, bool) {
+	link := sel.Find(BlurbTitle).First()
+	id := ParseSeriesID(link.AttrOr("href", ""))
+	if id == 0 {
+		return Series{}, false
+	}
+	desc, _ := sel.Find(BlurbSummary).First().Html()
+	return Series{
+		ID:          id,
+		Title:       strings.TrimSpace(link.Text()),
+		Creators:    getTextValues(sel.Find(BlurbAuthor)),
+		Updated:     parseBlurbDate(sel.Find(BlurbDate).First().Text()),
+		Description: strings.TrimSpace(desc),
+		Words:       parseCount(sel.Find(`dl.stats dd.words`).First().Text()),
+		Works:       parseCount(sel.Find(`dl.stats dd.works`).First().Text()),
+		Bookmarks:   parseCount(sel.Find(`dl.stats dd.bookmarks`).First().Text()),
+		Complete:    sel.Find(BlurbComplete).HasClass("complete-yes"),
+	}, true
+}
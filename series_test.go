@@ -0,0 +1,131 @@
+package ao3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestParseSeriesHTML(t *testing.T) {
+	f, err := os.Open("testdata/series.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	s, err := ParseSeriesHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Title != "Home Again" || s.ID != 1331351 {
+		t.Errorf("got series %q %d, expected Home Again 1331351", s.Title, s.ID)
+	}
+	if !slices.Equal(s.Creators, []string{"someone", "cowriter"}) {
+		t.Errorf("got creators %v, expected [someone cowriter]", s.Creators)
+	}
+	if d := s.Begun.Format("2006-01-02"); d != "2014-11-02" {
+		t.Errorf("got begun %s, expected 2014-11-02", d)
+	}
+	if d := s.Updated.Format("2006-01-02"); d != "2015-03-01" {
+		t.Errorf("got updated %s, expected 2015-03-01", d)
+	}
+	if s.Description != "<p>Road trips and homecomings.</p>" || s.Notes != "<p>Read in order.</p>" {
+		t.Errorf("got description %q and notes %q", s.Description, s.Notes)
+	}
+	if s.Words != 19999 || s.Works != 2 || s.Complete || s.Bookmarks != 87 {
+		t.Errorf("got words %d, works %d, complete %v, bookmarks %d, expected 19999, 2, false, 87",
+			s.Words, s.Works, s.Complete, s.Bookmarks)
+	}
+
+	if len(s.Members) != 2 {
+		t.Fatalf("got %d members, expected 2", len(s.Members))
+	}
+	for i, w := range s.Members {
+		if id := []int{2998877, 3221042}[i]; w.WorkID != id {
+			t.Errorf("member %d: got work %d, expected %d", i, w.WorkID, id)
+		}
+		if w.SeriesIndex != float64(i+1) || w.Series != "Home Again" {
+			t.Errorf("member %d: got %s %v, expected Home Again %d", i, w.Series, w.SeriesIndex, i+1)
+		}
+	}
+
+	m := s.StringMap()
+	if m["work_count"] != 2 || len(m["works"].([]map[string]any)) != 2 {
+		t.Errorf("got string map %v", m)
+	}
+}
+
+func TestSeriesFunc(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/series/") {
+			http.ServeFile(w, r, "testdata/series.html")
+			return
+		}
+		http.ServeFile(w, r, "testdata/work.html")
+	}))
+	defer srv.Close()
+
+	opts := DefaultOptions()
+	opts.RateLimit = 60000
+	opts.Jitter = 0
+
+	s, err := NewClient(opts).ScrapeSeriesContext(context.Background(), srv.URL+"/series/1331351")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Title != "Home Again" || s.ID != 1331351 {
+		t.Errorf("got series %q %d, expected Home Again 1331351", s.Title, s.ID)
+	}
+
+	// every work page says part 2, the series page order wins
+	var got []float64
+	for _, w := range s.Members {
+		got = append(got, w.SeriesIndex)
+	}
+	if !slices.Equal(got, []float64{1, 2}) {
+		t.Errorf("got series indexes %v, expected [1 2]", got)
+	}
+	if parts := s.Members[0].SeriesParts; len(parts) != 2 || parts[0].Position != 1 || parts[1].Name != "Pack Nights" {
+		t.Errorf("got series parts %+v, expected Home Again 1 then Pack Nights", parts)
+	}
+}
+
+func TestSeriesParts(t *testing.T) {
+	f, err := os.Open("testdata/work.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	work, err := ParseWorkHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []SeriesPart{
+		{ID: 1331351, Name: "Home Again", URL: "https://archiveofourown.org/series/1331351", Position: 2},
+		{ID: 2000001, Name: "Pack Nights", URL: "https://archiveofourown.org/series/2000001", Position: 5},
+	}
+	if !slices.Equal(work.SeriesParts, want) {
+		t.Errorf("got series parts %+v, expected %+v", work.SeriesParts, want)
+	}
+	if work.Series != "Home Again" || work.SeriesIndex != 2 {
+		t.Errorf("got series %s %v, expected Home Again 2", work.Series, work.SeriesIndex)
+	}
+
+	other, ok := work.StringMap()["other_series"].([]SeriesPart)
+	if !ok || !slices.Equal(other, want[1:]) {
+		t.Errorf("got other series %v, expected %v", other, want[1:])
+	}
+
+	work.setPrimarySeries(want[1])
+	if work.Series != "Pack Nights" || work.SeriesIndex != 5 || !slices.Equal(work.SeriesParts, []SeriesPart{want[1], want[0]}) {
+		t.Errorf("got series %s %v %+v, expected Pack Nights 5 first", work.Series, work.SeriesIndex, work.SeriesParts)
+	}
+}
@@ -0,0 +1,250 @@
+package ao3
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+const (
+	TagName     = `#main .primary.header h2.heading`
+	TagProfile  = `#main .tag.home.profile p`
+	TagParents  = `#main .parent.listbox a.tag`
+	TagSynonyms = `#main .synonym.listbox a.tag`
+	TagMetatags = `#main .meta.listbox a.tag`
+	TagSubtags  = `#main .sub.listbox a.tag`
+	TagChildren = `#main .characters.listbox a.tag, #main .relationships.listbox a.tag, #main .freeforms.listbox a.tag`
+	ListHeading = `#main h2.heading`
+)
+
+// TagType is the category of an ao3 tag.
+type TagType string
+
+const (
+	FandomTag         TagType = "fandom"
+	CharacterTag      TagType = "character"
+	RelationshipTag   TagType = "relationship"
+	FreeformTag       TagType = "freeform"
+	RatingTag         TagType = "rating"
+	ArchiveWarningTag TagType = "archive_warning"
+	CategoryTag       TagType = "category"
+	MediaTag          TagType = "media"
+	UnsortedTag       TagType = "unsorted"
+)
+
+var (
+	tagTypeRegexp    = regexp.MustCompile(`belongs to the (.+?) Category`)
+	worksCountRegexp = regexp.MustCompile(`([\d,]+) Works?\b`)
+
+	// tagEscapes are how ao3 writes the characters of a tag name that can't
+	// be in its url.
+	tagEscapes   = strings.NewReplacer("/", "*s*", "&", "*a*", ".", "*d*", "?", "*q*", "#", "*h*")
+	tagUnescapes = strings.NewReplacer("*s*", "/", "*a*", "&", "*d*", ".", "*q*", "?", "*h*", "#")
+)
+
+// Tag is an ao3 tag. A tag that isn't canonical may have been merged into
+// one that is, its MergedInto, and then it's one of that tag's Synonyms.
+type Tag struct {
+	Name       string
+	Type       TagType
+	Canonical  bool
+	MergedInto string
+	Synonyms   []string
+	Parents    []string
+	Children   []string
+	Metatags   []string
+	Subtags    []string
+	Works      int
+}
+
+// ScrapeTag scrapes the tag named name, or at the tag url name, with the
+// DefaultClient.
+func ScrapeTag(name string) (Tag, error) {
+	return DefaultClient().ScrapeTagContext(context.Background(), name)
+}
+
+// ScrapeTagContext is ScrapeTag, stopping when ctx is done.
+func ScrapeTagContext(ctx context.Context, name string) (Tag, error) {
+	return DefaultClient().ScrapeTagContext(ctx, name)
+}
+
+// CanonicalTag resolves a tag name to its canonical tag with the
+// DefaultClient.
+func CanonicalTag(ctx context.Context, name string) (string, error) {
+	return DefaultClient().CanonicalTag(ctx, name)
+}
+
+func (c *Client) ScrapeTag(name string) (Tag, error) {
+	return c.ScrapeTagContext(context.Background(), name)
+}
+
+// ScrapeTagContext scrapes the tag named name, or at the tag url name,
+// along with how many works it has.
+func (c *Client) ScrapeTagContext(ctx context.Context, name string) (Tag, error) {
+	f, err := c.NewFetcher()
+	if err != nil {
+		return Tag{}, err
+	}
+	defer f.Close()
+
+	tag, err := c.getTag(ctx, f, name)
+	if err != nil {
+		return tag, err
+	}
+
+	// a tag's works are listed under its canonical tag
+	canonical := tag.Name
+	if tag.MergedInto != "" {
+		canonical = tag.MergedInto
+	}
+	doc, err := c.fetchPage(ctx, f, TagURL(canonical)+"/works")
+	if err != nil {
+		return tag, err
+	}
+	tag.Works = parseWorksCount(doc)
+	return tag, nil
+}
+
+// CanonicalTag resolves the tag named name to the canonical tag it has been
+// merged into. Canonical tags, and tags that are neither canonical nor
+// merged, resolve to themselves.
+func (c *Client) CanonicalTag(ctx context.Context, name string) (string, error) {
+	f, err := c.NewFetcher()
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	tag, err := c.getTag(ctx, f, name)
+	if err != nil {
+		return "", err
+	}
+	if tag.MergedInto != "" {
+		return tag.MergedInto, nil
+	}
+	return tag.Name, nil
+}
+
+// CanonicalSearch returns s with its fandoms, characters, relationships and
+// additional tags resolved to their canonical tags.
+func (c *Client) CanonicalSearch(ctx context.Context, s WorkSearch) (WorkSearch, error) {
+	for _, names := range []*[]string{&s.Fandoms, &s.Characters, &s.Relationships, &s.Freeforms} {
+		resolved := make([]string, len(*names))
+		for i, n := range *names {
+			r, err := c.CanonicalTag(ctx, n)
+			if err != nil {
+				return s, err
+			}
+			resolved[i] = r
+		}
+		*names = resolved
+	}
+	return s, nil
+}
+
+func (c *Client) getTag(ctx context.Context, f Fetcher, name string) (Tag, error) {
+	u := name
+	if !strings.Contains(name, "/tags/") {
+		u = TagURL(name)
+	}
+	pu, err := ParseUrl(u)
+	if err != nil {
+		return Tag{}, err
+	}
+	doc, err := c.fetchPage(ctx, f, pu.String())
+	if err != nil {
+		return Tag{}, err
+	}
+	return parseTag(doc), nil
+}
+
+// ParseTagHTML reads a saved tag page.
+func ParseTagHTML(r io.Reader) (Tag, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return Tag{}, err
+	}
+	if err := CheckPage(doc); err != nil {
+		return Tag{}, err
+	}
+	return parseTag(doc), nil
+}
+
+// TagURL returns the url of the tag named name.
+func TagURL(name string) string {
+	p := url.PathEscape(tagEscapes.Replace(name))
+	return "https://" + ao3Host + "/tags/" + strings.ReplaceAll(p, "%2A", "*")
+}
+
+// ParseTagName returns the name of the tag at the tag url u.
+func ParseTagName(u string) string {
+	pu, err := url.Parse(u)
+	if err != nil {
+		return ""
+	}
+	_, p, ok := strings.Cut(pu.Path, "/tags/")
+	if !ok {
+		return ""
+	}
+	p, _, _ = strings.Cut(p, "/")
+	return tagUnescapes.Replace(p)
+}
+
+func parseTag(doc *goquery.Document) Tag {
+	profile := doc.Find(TagProfile).First()
+	text := strings.Join(strings.Fields(profile.Text()), " ")
+
+	tag := Tag{
+		Name:     strings.TrimSpace(doc.Find(TagName).First().Text()),
+		Synonyms: getTextValues(doc.Find(TagSynonyms)),
+		Parents:  getTextValues(doc.Find(TagParents)),
+		Children: getTextValues(doc.Find(TagChildren)),
+		Metatags: getTextValues(doc.Find(TagMetatags)),
+		Subtags:  getTextValues(doc.Find(TagSubtags)),
+	}
+	if m := tagTypeRegexp.FindStringSubmatch(text); len(m) > 0 {
+		tag.Type = parseTagType(m[1])
+	}
+	if strings.Contains(text, "synonym of") {
+		tag.MergedInto = strings.TrimSpace(profile.Find("a.tag").First().Text())
+	}
+	tag.Canonical = strings.Contains(text, "common tag") || strings.Contains(text, "canonical tag")
+	return tag
+}
+
+// parseTagType reads the name of a tag category, like Additional Tags.
+func parseTagType(s string) TagType {
+	switch s = strings.ToLower(s); {
+	case strings.Contains(s, "fandom"):
+		return FandomTag
+	case strings.Contains(s, "character"):
+		return CharacterTag
+	case strings.Contains(s, "relationship"):
+		return RelationshipTag
+	case strings.Contains(s, "additional") || strings.Contains(s, "freeform"):
+		return FreeformTag
+	case strings.Contains(s, "rating"):
+		return RatingTag
+	case strings.Contains(s, "warning"):
+		return ArchiveWarningTag
+	case strings.Contains(s, "categor"):
+		return CategoryTag
+	case strings.Contains(s, "media"):
+		return MediaTag
+	}
+	return UnsortedTag
+}
+
+// parseWorksCount reads the number of works from a listing's heading, like
+// 1 - 20 of 1,234 Works in Road Trips.
+func parseWorksCount(doc *goquery.Document) int {
+	m := worksCountRegexp.FindStringSubmatch(doc.Find(ListHeading).First().Text())
+	if len(m) == 0 {
+		return 0
+	}
+	return parseCount(m[1])
+}
@@ -0,0 +1,102 @@
+package ao3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/exp/slices"
+)
+
+func TestParseTagHTML(t *testing.T) {
+	f, err := os.Open("testdata/tag.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	tag, err := ParseTagHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tag.Name != "Road Trips" || tag.Type != FreeformTag || !tag.Canonical || tag.MergedInto != "" {
+		t.Errorf("got tag %+v, expected canonical freeform Road Trips", tag)
+	}
+	if !slices.Equal(tag.Parents, []string{"Teen Wolf (TV)", "No Fandom"}) {
+		t.Errorf("got parents %v", tag.Parents)
+	}
+	if !slices.Equal(tag.Synonyms, []string{"Roadtrip", "road trip AU"}) {
+		t.Errorf("got synonyms %v", tag.Synonyms)
+	}
+	if !slices.Equal(tag.Metatags, []string{"Travel"}) || !slices.Equal(tag.Subtags, []string{"Cross-Country Road Trips"}) {
+		t.Errorf("got metatags %v, subtags %v", tag.Metatags, tag.Subtags)
+	}
+	if !slices.Equal(tag.Children, []string{"Road Trip Snacks"}) {
+		t.Errorf("got children %v", tag.Children)
+	}
+
+	tag = parseTag(readTestdata(t, "tag_synonym"))
+	if tag.Name != "Roadtrip" || tag.Canonical || tag.MergedInto != "Road Trips" {
+		t.Errorf("got tag %+v, expected Roadtrip merged into Road Trips", tag)
+	}
+}
+
+func TestTagURL(t *testing.T) {
+	for name, want := range map[string]string{
+		"Road Trips":                  "https://archiveofourown.org/tags/Road%20Trips",
+		"Derek Hale/Stiles Stilinski": "https://archiveofourown.org/tags/Derek%20Hale*s*Stiles%20Stilinski",
+		"Dr. Who & Co?":               "https://archiveofourown.org/tags/Dr*d*%20Who%20*a*%20Co*q*",
+	} {
+		u := TagURL(name)
+		if u != want {
+			t.Errorf("TagURL(%q) = %s, expected %s", name, u, want)
+		}
+		if n := ParseTagName(u + "/works"); n != name {
+			t.Errorf("ParseTagName(%s) = %q, expected %q", u, n, name)
+		}
+	}
+}
+
+func TestParseWorksCount(t *testing.T) {
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(
+		`<div id="main"><h2 class="heading">1 - 20 of 12,345 Works in <a class="tag" href="/tags/Road%20Trips">Road Trips</a></h2></div>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := parseWorksCount(doc); n != 12345 {
+		t.Errorf("got %d works, expected 12345", n)
+	}
+}
+
+func TestCanonicalTag(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/tags/Roadtrip" {
+			http.ServeFile(w, r, "testdata/tag_synonym.html")
+			return
+		}
+		http.ServeFile(w, r, "testdata/tag.html")
+	}))
+	defer srv.Close()
+
+	opts := DefaultOptions()
+	opts.RateLimit = 60000
+	opts.Jitter = 0
+	c := NewClient(opts)
+
+	for path, want := range map[string]string{
+		"/tags/Roadtrip":   "Road Trips",
+		"/tags/Road Trips": "Road Trips",
+	} {
+		got, err := c.CanonicalTag(context.Background(), srv.URL+path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Errorf("%s: got %q, expected %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,229 @@
+package ao3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/spf13/cast"
+)
+
+const (
+	ProfileName  = `.user.profile h2.heading`
+	ProfileMeta  = `.user.profile dl.meta > dt`
+	ProfileBio   = `.user.profile .bio`
+	SeriesBlurbs = `li.series.blurb`
+)
+
+// The listings of a user or pseud.
+const (
+	WorksListing     = "works"
+	SeriesListing    = "series"
+	BookmarksListing = "bookmarks"
+	GiftsListing     = "gifts"
+)
+
+var userPathRegexp = regexp.MustCompile(`^/users/[^/]+(?:/pseuds/[^/]+)?`)
+
+// User is an ao3 user's profile.
+type User struct {
+	Name   string
+	ID     int
+	Pseuds []string
+	Joined time.Time
+	Bio    string
+}
+
+// ScrapeUser scrapes the profile of the user u, a user name or the url of
+// any of their pages, with the DefaultClient.
+func ScrapeUser(u string) (User, error) {
+	return DefaultClient().ScrapeUserContext(context.Background(), u)
+}
+
+// ScrapeUserContext is ScrapeUser, stopping when ctx is done.
+func ScrapeUserContext(ctx context.Context, u string) (User, error) {
+	return DefaultClient().ScrapeUserContext(ctx, u)
+}
+
+// UserListFunc calls fn with every work of the user or pseud u's works,
+// bookmarks or gifts, with the DefaultClient.
+func UserListFunc(ctx context.Context, u, listing string, fn WorkFunc) error {
+	return DefaultClient().UserListFunc(ctx, u, listing, fn)
+}
+
+// UserSeries returns the series of the user or pseud u, with the
+// DefaultClient.
+func UserSeries(ctx context.Context, u string) ([]Series, error) {
+	return DefaultClient().UserSeries(ctx, u)
+}
+
+func (c *Client) ScrapeUser(u string) (User, error) {
+	return c.ScrapeUserContext(context.Background(), u)
+}
+
+// ScrapeUserContext scrapes the profile of the user u, a user name or the
+// url of any of their pages. A pseud's profile is its user's.
+func (c *Client) ScrapeUserContext(ctx context.Context, u string) (User, error) {
+	p, err := userPath(u)
+	if err != nil {
+		return User{}, err
+	}
+	name, _, _ := strings.Cut(strings.TrimPrefix(p, "/users/"), "/")
+	pu, err := ParseUrl("/users/" + name + "/profile")
+	if err != nil {
+		return User{}, err
+	}
+
+	f, err := c.NewFetcher()
+	if err != nil {
+		return User{}, err
+	}
+	defer f.Close()
+
+	doc, err := c.fetchPage(ctx, f, pu.String())
+	if err != nil {
+		return User{}, err
+	}
+	return parseUser(doc), nil
+}
+
+// UserListFunc calls fn with every work of the user or pseud u's works,
+// bookmarks or gifts listing, page after page. u is a user name or the url
+// of any of their pages; pseud urls list only that pseud's.
+func (c *Client) UserListFunc(ctx context.Context, u, listing string, fn WorkFunc) error {
+	if listing == SeriesListing {
+		return fmt.Errorf("%w %q: series aren't works, see UserSeries", ErrUserListing, listing)
+	}
+	lu, err := userListURL(u, listing)
+	if err != nil {
+		return err
+	}
+	return c.walkList(ctx, lu, fn)
+}
+
+// UserSeries returns the series of the user or pseud u, built from their
+// blurbs, without their members.
+func (c *Client) UserSeries(ctx context.Context, u string) ([]Series, error) {
+	lu, err := userListURL(u, SeriesListing)
+	if err != nil {
+		return nil, err
+	}
+	var series []Series
+	err = c.crawl(ctx, lu, func(_ context.Context, _ Fetcher, _ string, doc *goquery.Document, _, limit int) (int, bool, error) {
+		page := parseSeriesBlurbs(doc)
+		if limit > 0 && len(page) > limit {
+			page = page[:limit]
+		}
+		series = append(series, page...)
+		return len(page), false, nil
+	})
+	return series, err
+}
+
+// ParseUserHTML reads a saved profile page.
+func ParseUserHTML(r io.Reader) (User, error) {
+	doc, err := goquery.NewDocumentFromReader(r)
+	if err != nil {
+		return User{}, err
+	}
+	if err := CheckPage(doc); err != nil {
+		return User{}, err
+	}
+	return parseUser(doc), nil
+}
+
+// UserURL returns the url of a user's page, or of one of their pseuds when
+// pseud isn't empty.
+func UserURL(name, pseud string) string {
+	u := "https://" + ao3Host + "/users/" + url.PathEscape(name)
+	if pseud != "" {
+		u += "/pseuds/" + url.PathEscape(pseud)
+	}
+	return u
+}
+
+// StringMap converts a user to map[string]any.
+func (u User) StringMap() map[string]any {
+	m := map[string]any{
+		"name": u.Name,
+		"url":  UserURL(u.Name, ""),
+	}
+	if v := u.ID; v != 0 {
+		m["user_id"] = v
+	}
+	if v := u.Pseuds; len(v) != 0 {
+		m["pseuds"] = v
+	}
+	if v := u.Joined; !v.IsZero() {
+		m["joined"] = v
+	}
+	if v := u.Bio; v != "" {
+		m["bio"] = v
+	}
+	return m
+}
+
+func parseUser(doc *goquery.Document) User {
+	user := User{
+		Name: strings.TrimSpace(doc.Find(ProfileName).First().Text()),
+		Bio:  userstuff(doc.Find(ProfileBio)),
+	}
+	doc.Find(ProfileMeta).Each(func(_ int, dt *goquery.Selection) {
+		dd := dt.NextFiltered("dd")
+		switch label := strings.TrimSpace(dt.Text()); {
+		case strings.Contains(label, "pseuds"):
+			user.Pseuds = getTextValues(dd.Find("a"))
+		case strings.Contains(label, "joined"):
+			user.Joined = parseSeriesDate(dd.Text())
+		case strings.Contains(label, "user ID"):
+			user.ID = cast.ToInt(strings.TrimSpace(dd.Text()))
+		}
+	})
+	return user
+}
+
+func parseSeriesBlurbs(doc *goquery.Document) []Series {
+	var series []Series
+	doc.Find(SeriesBlurbs).Each(func(_ int, sel *goquery.Selection) {
+		if s, ok := parseSeriesBlurb(sel); ok {
+			series = append(series, s)
+		}
+	})
+	return series
+}
+
+// userListURL returns the url of the user or pseud u's listing.
+func userListURL(u, listing string) (*url.URL, error) {
+	switch listing {
+	case WorksListing, SeriesListing, BookmarksListing, GiftsListing:
+	default:
+		return nil, fmt.Errorf("%w %q", ErrUserListing, listing)
+	}
+	p, err := userPath(u)
+	if err != nil {
+		return nil, err
+	}
+	return ParseUrl(p + "/" + listing)
+}
+
+// userPath returns the path of the user or pseud u, a user name or the url
+// of any of their pages.
+func userPath(u string) (string, error) {
+	if !strings.Contains(u, "/") {
+		return "/users/" + url.PathEscape(u), nil
+	}
+	pu, err := url.Parse(u)
+	if err != nil {
+		return "", newError(u, "", fmt.Errorf("%w: %w", ErrParse, err))
+	}
+	p := userPathRegexp.FindString(pu.EscapedPath())
+	if p == "" {
+		return "", newError(u, "", fmt.Errorf("%w: not a user url", ErrParse))
+	}
+	return p, nil
+}
@@ -0,0 +1,89 @@
+package ao3
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestParseUserHTML(t *testing.T) {
+	f, err := os.Open("testdata/profile.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	u, err := ParseUserHTML(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if u.Name != "someone" || u.ID != 1001 {
+		t.Errorf("got user %q %d, expected someone 1001", u.Name, u.ID)
+	}
+	if !slices.Equal(u.Pseuds, []string{"someone", "Roadie"}) {
+		t.Errorf("got pseuds %v, expected [someone Roadie]", u.Pseuds)
+	}
+	if d := u.Joined.Format("2006-01-02"); d != "2012-06-14" {
+		t.Errorf("got joined %s, expected 2012-06-14", d)
+	}
+	if u.Bio != "<p>Writes about road trips.</p>" {
+		t.Errorf("got bio %q", u.Bio)
+	}
+}
+
+func TestUserListURL(t *testing.T) {
+	for _, test := range []struct {
+		user, listing, want string
+	}{
+		{"someone", WorksListing, "/users/someone/works"},
+		{"some one", GiftsListing, "/users/some%20one/gifts"},
+		{"https://archiveofourown.org/users/someone/profile", BookmarksListing, "/users/someone/bookmarks"},
+		{"https://archiveofourown.org/users/someone/pseuds/Roadie/works?page=2", SeriesListing, "/users/someone/pseuds/Roadie/series"},
+	} {
+		u, err := userListURL(test.user, test.listing)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if u.Host != ao3Host || u.EscapedPath() != test.want {
+			t.Errorf("%s %s: got %s, expected %s", test.user, test.listing, u, test.want)
+		}
+	}
+
+	if _, err := userListURL("someone", "kudos"); !errors.Is(err, ErrUserListing) {
+		t.Errorf("got %v for an unknown listing, expected ErrUserListing", err)
+	}
+	if err := UserListFunc(context.Background(), "someone", SeriesListing, nil); !errors.Is(err, ErrUserListing) {
+		t.Errorf("got %v listing series as works, expected ErrUserListing", err)
+	}
+	if _, err := userListURL("https://archiveofourown.org/works/1", WorksListing); err == nil {
+		t.Error("got no error for a work url")
+	}
+}
+
+func TestParseSeriesBlurbs(t *testing.T) {
+	series := parseSeriesBlurbs(readTestdata(t, "user_series"))
+	if len(series) != 2 {
+		t.Fatalf("got %d series, expected 2", len(series))
+	}
+
+	s := series[0]
+	if s.ID != 1331351 || s.Title != "Home Again" || s.Complete {
+		t.Errorf("got series %d %q complete %v, expected 1331351 Home Again in progress", s.ID, s.Title, s.Complete)
+	}
+	if !slices.Equal(s.Creators, []string{"someone", "cowriter"}) {
+		t.Errorf("got creators %v", s.Creators)
+	}
+	if s.Words != 19999 || s.Works != 2 || s.Bookmarks != 87 {
+		t.Errorf("got words %d, works %d, bookmarks %d", s.Words, s.Works, s.Bookmarks)
+	}
+	if d := s.Updated.Format("2006-01-02"); d != "2015-03-01" {
+		t.Errorf("got updated %s, expected 2015-03-01", d)
+	}
+
+	if s := series[1]; !s.Complete || s.Works != 5 || s.Description != "" {
+		t.Errorf("got %+v, expected a complete series of 5 works", s)
+	}
+}
@@ -0,0 +1,155 @@
+package ao3
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/PuerkitoBio/goquery"
+)
+
+// StopCrawl can be returned by a WorkFunc to stop a crawl early without
+// error.
+var StopCrawl = errors.New("stop crawl")
+
+// WorkFunc is called with each work of a listing as soon as it's scraped, in
+// listing order. Returning an error stops the crawl, and the error is
+// returned by the crawl unless it's StopCrawl.
+type WorkFunc func(Work) error
+
+// ListFunc calls fn with every work of the listing at u, page after page,
+// with the DefaultClient.
+func ListFunc(ctx context.Context, u string, fn WorkFunc) error {
+	return DefaultClient().ListFunc(ctx, u, fn)
+}
+
+// ListFunc calls fn with every work of the listing at u, such as a series,
+// bookmarks or a tag's works, page after page. Works that fail are skipped
+// and their errors returned together as ScrapeErrors once the crawl is done.
+func (c *Client) ListFunc(ctx context.Context, u string, fn WorkFunc) error {
+	lu, err := ParseUrl(u)
+	if err != nil {
+		return err
+	}
+	return c.walkList(ctx, lu, fn)
+}
+
+// walkList crawls the pages of the listing at u, from the client's
+// StartPage until the last page, MaxPages or MaxResults, calling fn with
+// each work.
+func (c *Client) walkList(ctx context.Context, u *url.URL, fn WorkFunc) error {
+	return c.crawl(ctx, u, c.workPages(nil, fn))
+}
+
+// pageFunc handles a page of a listing fetched through f, returning how many
+// of its first limit results it handled, all of them when limit is 0, and
+// whether the crawl should stop there.
+type pageFunc func(ctx context.Context, f Fetcher, u string, doc *goquery.Document, page, limit int) (int, bool, error)
+
+// workPages returns a pageFunc calling fn with each work of a page, after
+// calling onPage, when set, with the page itself.
+func (c *Client) workPages(onPage func(int, *goquery.Document), fn WorkFunc) pageFunc {
+	return func(ctx context.Context, f Fetcher, u string, doc *goquery.Document, page, limit int) (int, bool, error) {
+		if onPage != nil {
+			onPage(page, doc)
+		}
+		var stopped bool
+		n, err := c.walkPage(ctx, f, u, doc, limit, func(w Work) error {
+			err := fn(w)
+			stopped = err != nil
+			return err
+		})
+		return n, stopped, err
+	}
+}
+
+// crawl fetches the pages of the listing at u, from the client's StartPage
+// until the last page, MaxPages or MaxResults, handing each to handle.
+func (c *Client) crawl(ctx context.Context, u *url.URL, handle pageFunc) error {
+	f, err := c.NewFetcher()
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	first := c.opts.StartPage
+	if first < 1 {
+		first = 1
+	}
+
+	var (
+		errs    ScrapeErrors
+		results int
+		total   = first
+	)
+	params := u.Query()
+	for page := first; page <= total && ctx.Err() == nil; page++ {
+		if c.opts.MaxPages > 0 && page-first >= c.opts.MaxPages {
+			break
+		}
+
+		params.Set("page", strconv.Itoa(page))
+		u.RawQuery = params.Encode()
+
+		doc, err := c.fetchPage(ctx, f, u.String())
+		if err != nil {
+			return append(errs, err)
+		}
+		if n := parseTotalPages(doc); n > total {
+			total = n
+		}
+
+		limit := 0
+		if c.opts.MaxResults > 0 {
+			limit = c.opts.MaxResults - results
+		}
+		n, stopped, err := handle(ctx, f, u.String(), doc, page, limit)
+		results += n
+
+		var se ScrapeErrors
+		switch {
+		case errors.As(err, &se):
+			errs = append(errs, se...)
+		case err != nil:
+			return err
+		}
+		if stopped || (c.opts.MaxResults > 0 && results >= c.opts.MaxResults) {
+			break
+		}
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// walkPage calls fn with the first limit works listed on doc, or all of them
+// when limit is 0, returning how many were listed. In blurb mode the works
+// are built from the blurbs, and only fetched when the client's DeepFetch
+// asks for it.
+func (c *Client) walkPage(ctx context.Context, f Fetcher, u string, doc *goquery.Document, limit int, fn WorkFunc) (int, error) {
+	if !c.opts.Blurbs {
+		links, err := parseLinkList(doc)
+		if err != nil {
+			return 0, withURL(err, u)
+		}
+		if limit > 0 && len(links) > limit {
+			links = links[:limit]
+		}
+		return len(links), c.walkWorks(ctx, f, links, fn)
+	}
+
+	blurbs := parseBlurbs(doc, c.opts.Podfic)
+	if limit > 0 && len(blurbs) > limit {
+		blurbs = blurbs[:limit]
+	}
+	return len(blurbs), c.walk(ctx, len(blurbs), func(ctx context.Context, i int) (Work, error) {
+		w := blurbs[i]
+		if c.opts.DeepFetch == nil || !c.opts.DeepFetch(w) {
+			return w, nil
+		}
+		return c.GetWork(ctx, f, w.CanonicalURL())
+	}, fn)
+}
@@ -0,0 +1,99 @@
+package ao3
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/PuerkitoBio/goquery"
+	"golang.org/x/exp/slices"
+)
+
+// listingServer serves a listing of 5 pages, each linking 3 works numbered
+// after the page, and the work fixture for every work. Requests are counted
+// in requests when it isn't nil.
+func listingServer(requests *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests != nil {
+			atomic.AddInt32(requests, 1)
+		}
+		if r.URL.Path != "/listing" {
+			http.ServeFile(w, r, "testdata/work.html")
+			return
+		}
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		fmt.Fprint(w, `<html><body><ol class="pagination actions">`)
+		for i := 1; i <= 5; i++ {
+			fmt.Fprintf(w, `<li><a href="/listing?page=%d">%d</a></li>`, i, i)
+		}
+		fmt.Fprint(w, `</ol><ol class="work index group">`)
+		for i := 1; i <= 3; i++ {
+			fmt.Fprintf(w, `<li class="work blurb"><h4 class="heading"><a href="/works/%d%d">work</a></h4></li>`, page, i)
+		}
+		fmt.Fprint(w, `</ol></body></html>`)
+	}))
+}
+
+func TestWalkList(t *testing.T) {
+	srv := listingServer(nil)
+	defer srv.Close()
+
+	for _, test := range []struct {
+		start, pages, results int
+		want                  []int
+	}{
+		{0, 0, 0, []int{11, 12, 13, 21, 22, 23, 31, 32, 33, 41, 42, 43, 51, 52, 53}},
+		{4, 0, 0, []int{41, 42, 43, 51, 52, 53}},
+		{2, 2, 0, []int{21, 22, 23, 31, 32, 33}},
+		{2, 0, 5, []int{21, 22, 23, 31, 32}},
+		{5, 3, 0, []int{51, 52, 53}},
+	} {
+		opts := DefaultOptions()
+		opts.RateLimit = 60000
+		opts.Jitter = 0
+		opts.StartPage = test.start
+		opts.MaxPages = test.pages
+		opts.MaxResults = test.results
+
+		var ids []int
+		err := NewClient(opts).ListFunc(context.Background(), srv.URL+"/listing", func(w Work) error {
+			ids = append(ids, w.WorkID)
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(ids, test.want) {
+			t.Errorf("start %d, max pages %d, max results %d: got works %v, expected %v",
+				test.start, test.pages, test.results, ids, test.want)
+		}
+	}
+}
+
+func TestParseTotalPages(t *testing.T) {
+	for name, want := range map[string]int{
+		"search":    198,
+		"bookmarks": 2,
+		"work":      1,
+	} {
+		if got := parseTotalPages(readTestdata(t, name)); got != want {
+			t.Errorf("%s: got %d pages, expected %d", name, got, want)
+		}
+	}
+
+	// a single link used to index past the start of the pagination
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(
+		`<ol class="pagination actions"><li><a href="?page=2">2</a></li></ol>`,
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := parseTotalPages(doc); got != 2 {
+		t.Errorf("got %d pages, expected 2", got)
+	}
+}
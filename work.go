@@ -5,30 +5,32 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
 	"time"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/chromedp/cdproto/cdp"
 	"github.com/chromedp/chromedp"
-	"github.com/ohzqq/cdb"
 	"github.com/spf13/cast"
 )
 
 const (
-	Title        = `h2.title`
-	Author       = `h3.byline a`
-	Series       = `dd.series .position`
-	Comments     = `.preface .summary .userstuff`
-	Ships        = `dd.relationship a`
-	Tags         = `dd.freeform a`
-	Fandom       = `dd.fandom a`
-	Pubdate      = `dd.published`
-	ListLink     = `li.work h4.heading a:first-of-type`
-	RelatedWorks = `ul.associations li a`
-	Downloads    = `li.download ul li a`
+	Title          = `h2.title`
+	Author         = `h3.byline a`
+	SeriesPosition = `dd.series .position`
+	Comments       = `.preface .summary .userstuff`
+	Ships          = `dd.relationship a`
+	Tags           = `dd.freeform a`
+	Fandom         = `dd.fandom a`
+	Pubdate        = `dd.published`
+	ListLink       = `li.work h4.heading a:first-of-type, li.bookmark h4.heading a:first-of-type`
+	RelatedWorks   = `ul.associations li a`
+	Downloads      = `li.download ul li a`
 )
 
 func GetString(sel string, val *string) chromedp.Action {
@@ -67,29 +69,88 @@ func GetInnerHTML(sel string, val *string) chromedp.Action {
 	))
 }
 
-func getSeries(ctx context.Context, book *cdb.Book) {
-	var s string
-	err := chromedp.Run(ctx,
-		chromedp.TextContent(
-			Series,
-			&s,
-			chromedp.ByQuery,
-			chromedp.NodeReady,
-			chromedp.AtLeast(0),
-		),
-	)
+func parseWork(doc *goquery.Document, podfic bool) (Work, error) {
+	var work Work
+
+	comments, _ := doc.Find(Comments).First().Html()
+
+	work.setID(parseWorkID(doc))
+	work.Meta = parseWorkMeta(doc)
+	work.Stats = parseWorkStats(doc)
+	work.Chapters = parseChapters(doc)
+	work.WorkAssociations = parseAssociations(doc)
+	work.Notes = getInnerHTML(doc.Find(WorkNotes))
+	work.EndNotes = getInnerHTML(doc.Find(WorkEndNotes))
+
+	work.Title = strings.TrimSpace(doc.Find(Title).First().Text())
+	if work.Title == "" {
+		return work, newError("", Title, ErrParse)
+	}
+
+	formats, err := parseFormats(doc.Find(Downloads))
 	if err != nil {
-		fmt.Errorf("%w %w\n", scrapeErr("series"), err)
-		return
+		return work, err
+	}
+
+	work.Comments = strings.ReplaceAll(comments, "\n", "")
+	work.Pubdate = work.Stats.Published
+	work.Formats = formats
+	work.Tags = work.Meta.Freeforms
+	if l := work.Meta.Language; l != "" {
+		work.Languages = []string{l}
+	}
+
+	auth := getTextValues(doc.Find(Author))
+	if podfic {
+		work.Narrators = auth
+		// the authors of a podfic are those of the works it's inspired by
+		for _, rel := range work.InspiredBy {
+			work.Authors = append(work.Authors, rel.Authors...)
+		}
+	} else {
+		work.Authors = auth
 	}
 
-	title, pos, err := parseSeriesText(s)
+	work.setSeries(parseSeriesParts(doc.Find(SeriesPosition)))
+
+	return work, nil
+}
+
+// parseLinkList returns the work links of a listing, leaving out the series
+// and external works a bookmark listing also has.
+func parseLinkList(doc *goquery.Document) ([]string, error) {
+	links, err := parseLinks(doc.Find(ListLink), ListLink, doc.Url)
 	if err != nil {
-		fmt.Errorf("series parsing err: %w\n", err)
+		return links, err
+	}
+	works := links[:0]
+	for _, l := range links {
+		if id, _ := ParseWorkID(l); id != 0 {
+			works = append(works, l)
+		}
 	}
+	return works, nil
+}
 
-	book.Series = title
-	book.SeriesIndex = pos
+// parseLinks returns the hrefs of the selection as full work urls, resolved
+// against base when the page has one.
+func parseLinks(sel *goquery.Selection, selector string, base *url.URL) ([]string, error) {
+	links := make([]string, 0, sel.Length())
+	var err error
+	sel.EachWithBreak(func(_ int, node *goquery.Selection) bool {
+		href := node.AttrOr("href", "")
+		if ref, rerr := url.Parse(href); rerr == nil && base != nil {
+			href = base.ResolveReference(ref).String()
+		}
+		t, perr := ParseUrl(href)
+		if perr != nil {
+			err = newError("", selector, fmt.Errorf("%w: bad link %q", ErrParse, href))
+			return false
+		}
+		links = append(links, t.String())
+		return true
+	})
+	return links, err
 }
 
 var NoTitleErr = errors.New("no title")
@@ -106,7 +167,7 @@ func parseSeriesText(s string) (string, float64, error) {
 	matches := seriesRegexp.FindStringSubmatch(s)
 
 	if len(matches) < 1 {
-		return title, pos, fmt.Errorf("no matches for %s\n", s)
+		return title, pos, fmt.Errorf("%w: no series matches for %q", ErrParse, s)
 	}
 
 	pos = cast.ToFloat64(matches[seriesRegexp.SubexpIndex("pos")])
@@ -122,68 +183,77 @@ func parsePubdate(pubdate string) time.Time {
 	return t
 }
 
-func parseFormats(nodes []*cdp.Node) []string {
-	formats := make([]string, len(nodes))
-	for i, node := range nodes {
-		t := node.AttributeValue("href")
-		formats[i] = ParseUrl(t).String()
-	}
-	return formats
+func parseFormats(sel *goquery.Selection) ([]string, error) {
+	return parseLinks(sel, Downloads, nil)
 }
 
-func parseRelated(nodes []*cdp.Node) []string {
-	var rels []string
-	for _, node := range nodes {
-		if rel := node.AttributeValue("rel"); rel == "author" {
-			rels = append(rels, node.Children[0].NodeValue)
-		}
-	}
-	return rels
+func getTextValues(sel *goquery.Selection) []string {
+	var vals []string
+	sel.Each(func(_ int, node *goquery.Selection) {
+		vals = append(vals, strings.TrimSpace(node.Text()))
+	})
+	return vals
 }
 
-func parseTags(nodes ...[]*cdp.Node) []string {
-	var tags []string
-	for _, n := range nodes {
-		tags = append(tags, getFirstChildValues(n)...)
-	}
-	return tags
+// DownloadWork saves the download link u to the file name with the
+// DefaultClient.
+func DownloadWork(u, name string) error {
+	return DefaultClient().DownloadWorkContext(context.Background(), u, name)
 }
 
-func getFirstChildValues(nodes []*cdp.Node) []string {
-	var vals []string
-	for _, node := range nodes {
-		var t string
-		if len(node.Children) > 0 {
-			t = node.Children[0].NodeValue
-		}
-		vals = append(vals, t)
-	}
-	return vals
+// DownloadWorkContext is DownloadWork, giving up when ctx is done.
+func DownloadWorkContext(ctx context.Context, u, name string) error {
+	return DefaultClient().DownloadWorkContext(ctx, u, name)
+}
+
+// DownloadWork saves the download link u to the file name.
+func (c *Client) DownloadWork(u, name string) error {
+	return c.DownloadWorkContext(context.Background(), u, name)
 }
 
-func DownloadWork(u, name string) {
-	response, err := http.Get(u)
+// DownloadWorkContext saves the download link u to the file name, giving up
+// when ctx is done.
+func (c *Client) DownloadWorkContext(ctx context.Context, u, name string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
 	if err != nil {
-		log.Fatal(err)
+		return newError(u, "", err)
 	}
-	defer response.Body.Close()
+	req.Header.Set("User-Agent", strings.TrimPrefix(userAgent, "user-agent="))
 
-	if response.StatusCode != 200 {
-		log.Fatal(response.StatusCode)
+	var response *http.Response
+	err = c.limiter.Do(ctx, func() error {
+		res, err := c.download.Do(req)
+		if err != nil {
+			return newError(u, "", err)
+		}
+		if res.StatusCode != http.StatusOK {
+			res.Body.Close()
+			return statusError(u, res.StatusCode, res.Header)
+		}
+		response = res
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	defer response.Body.Close()
 
-	file, err := os.Create(name)
+	// write to a temporary file so an interrupted download doesn't leave a
+	// truncated file under name
+	file, err := os.CreateTemp(filepath.Dir(name), "."+filepath.Base(name)+".*")
 	if err != nil {
-		log.Fatal(err)
+		return err
 	}
-	defer file.Close()
-
 	_, err = io.Copy(file, response.Body)
+	if cerr := file.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(file.Name(), name)
+	}
 	if err != nil {
-		log.Fatal(err)
+		os.Remove(file.Name())
+		return newError(u, "", err)
 	}
-}
-
-func scrapeErr(name string) error {
-	return fmt.Errorf("error scraping %s from %s\n", name, CurrentURL())
+	return nil
 }
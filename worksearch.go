@@ -0,0 +1,253 @@
+package ao3
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	searchWarningIDs  = `work_search[archive_warning_ids][]`
+	searchCategoryIDs = `work_search[category_ids][]`
+	searchCommit      = `commit`
+)
+
+// Sort columns of a work search.
+const (
+	SortBestMatch = `_score`
+	SortAuthor    = `authors_to_sort_on`
+	SortTitle     = `title_to_sort_on`
+	SortPosted    = `created_at`
+	SortUpdated   = `revised_at`
+	SortWords     = `word_count`
+	SortHits      = `hits`
+	SortKudos     = `kudos_count`
+	SortComments  = `comments_count`
+	SortBookmarks = `bookmarks_count`
+)
+
+// Sort directions of a work search.
+const (
+	SortAsc  = `asc`
+	SortDesc = `desc`
+)
+
+// WorkSearch holds the fields of ao3's work search form. Empty fields are
+// left out of the search.
+type WorkSearch struct {
+	AnyField      string
+	Title         string
+	Creators      string
+	RevisedAt     string
+	Fandoms       []string
+	Characters    []string
+	Relationships []string
+	Freeforms     []string
+	Rating        Rating
+	Warnings      []ArchiveWarning
+	Categories    []Category
+	// Language is an ao3 language code, tag or name, see LookupLanguage.
+	Language  string
+	Words     Range
+	Hits      Range
+	Kudos     Range
+	Comments  Range
+	Bookmarks Range
+	// Complete and Crossover search for only matching works when true,
+	// excluding them when false, and for both when nil.
+	Complete      *bool
+	Crossover     *bool
+	SingleChapter bool
+	SortColumn    string
+	SortDirection string
+}
+
+// Range is an inclusive range of a work stat. A zero bound is open.
+type Range struct {
+	Min int
+	Max int
+}
+
+// ParseWorkSearch reads the fields of a work search url.
+func ParseWorkSearch(u string) (WorkSearch, error) {
+	var s WorkSearch
+
+	q, err := ParseURL(u)
+	if err != nil {
+		return s, err
+	}
+	v := q.Query
+
+	s.AnyField = v.Get(searchQuery)
+	s.Title = v.Get(searchTitle)
+	s.Creators = v.Get(searchCreators)
+	s.RevisedAt = v.Get(searchRevisedAt)
+	s.Fandoms = splitNames(v.Get(searchFandomNames))
+	s.Characters = splitNames(v.Get(searchCharNames))
+	s.Relationships = splitNames(v.Get(searchRelNames))
+	s.Freeforms = splitNames(v.Get(searchTags))
+	s.Rating = firstOf(parseIDs[Rating](v[searchRatingIDs]))
+	s.Warnings = parseIDs[ArchiveWarning](v[searchWarningIDs])
+	s.Categories = parseIDs[Category](v[searchCategoryIDs])
+	s.Language = v.Get(searchLangId)
+	s.Words = ParseRange(v.Get(searchWordCount))
+	s.Hits = ParseRange(v.Get(searchHits))
+	s.Kudos = ParseRange(v.Get(searchKudosCount))
+	s.Comments = ParseRange(v.Get(searchCommentsCount))
+	s.Bookmarks = ParseRange(v.Get(searchBookmarksCount))
+	s.Complete = parseTF(v.Get(searchComplete))
+	s.Crossover = parseTF(v.Get(searchCrossover))
+	s.SingleChapter = v.Get(searchSingleChapter) == "1"
+	s.SortColumn = v.Get(searchSortCol)
+	s.SortDirection = v.Get(searchSortDirection)
+
+	return s, nil
+}
+
+// Query renders the search as a url on ao3.
+func (s WorkSearch) Query() *Query {
+	q, _ := New()
+	q.SetHost(ao3Host)
+	q.AppendPath("/works", "search")
+
+	set := func(k, v string) {
+		if v != "" {
+			q.SetParam(k, v)
+		}
+	}
+	set(searchQuery, s.AnyField)
+	set(searchTitle, s.Title)
+	set(searchCreators, s.Creators)
+	set(searchRevisedAt, s.RevisedAt)
+	set(searchFandomNames, strings.Join(s.Fandoms, ","))
+	set(searchCharNames, strings.Join(s.Characters, ","))
+	set(searchRelNames, strings.Join(s.Relationships, ","))
+	set(searchTags, strings.Join(s.Freeforms, ","))
+	if s.Rating != 0 {
+		set(searchRatingIDs, strconv.Itoa(s.Rating.ID()))
+	}
+	for _, w := range s.Warnings {
+		q.AddParam(searchWarningIDs, strconv.Itoa(w.ID()))
+	}
+	for _, c := range s.Categories {
+		q.AddParam(searchCategoryIDs, strconv.Itoa(c.ID()))
+	}
+	lang := s.Language
+	if l, ok := LookupLanguage(lang); ok {
+		lang = l.Code
+	}
+	set(searchLangId, lang)
+	set(searchWordCount, s.Words.String())
+	set(searchHits, s.Hits.String())
+	set(searchKudosCount, s.Kudos.String())
+	set(searchCommentsCount, s.Comments.String())
+	set(searchBookmarksCount, s.Bookmarks.String())
+	set(searchComplete, formatTF(s.Complete))
+	set(searchCrossover, formatTF(s.Crossover))
+	if s.SingleChapter {
+		set(searchSingleChapter, "1")
+	}
+	set(searchSortCol, s.SortColumn)
+	set(searchSortDirection, s.SortDirection)
+	q.SetParam(searchCommit, "Search")
+
+	return q
+}
+
+// Validate checks the search's language is one ao3 knows.
+func (s WorkSearch) Validate() error {
+	if s.Language != "" {
+		if _, err := ParseLanguage(s.Language); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String returns the search url.
+func (s WorkSearch) String() string {
+	return s.Query().String()
+}
+
+// ParseRange reads a range in ao3's search syntax: 10-20, >10, <20 or 10.
+func ParseRange(s string) Range {
+	var r Range
+	s = strings.ReplaceAll(strings.TrimSpace(s), ",", "")
+	switch {
+	case s == "":
+	case strings.HasPrefix(s, ">"):
+		r.Min = parseCount(s[1:]) + 1
+	case strings.HasPrefix(s, "<"):
+		r.Max = parseCount(s[1:]) - 1
+	case strings.Contains(s, "-"):
+		min, max, _ := strings.Cut(s, "-")
+		r.Min, r.Max = parseCount(min), parseCount(max)
+	default:
+		r.Min = parseCount(s)
+		r.Max = r.Min
+	}
+	return r
+}
+
+// String formats the range in ao3's search syntax, empty when both bounds
+// are open.
+func (r Range) String() string {
+	switch {
+	case r.Min > 0 && r.Max > 0 && r.Min == r.Max:
+		return strconv.Itoa(r.Min)
+	case r.Min > 0 && r.Max > 0:
+		return fmt.Sprintf("%d-%d", r.Min, r.Max)
+	case r.Min > 0:
+		return fmt.Sprintf(">%d", r.Min-1)
+	case r.Max > 0:
+		return fmt.Sprintf("<%d", r.Max+1)
+	}
+	return ""
+}
+
+// splitNames splits the comma separated tag names of a search field.
+func splitNames(s string) []string {
+	var names []string
+	for _, n := range strings.Split(s, ",") {
+		if n = strings.TrimSpace(n); n != "" {
+			names = append(names, n)
+		}
+	}
+	return names
+}
+
+// parseIDs reads ao3 tag ids.
+func parseIDs[T ~int](vals []string) []T {
+	var ids []T
+	for _, v := range vals {
+		if id, err := strconv.Atoi(v); err == nil {
+			ids = append(ids, T(id))
+		}
+	}
+	return ids
+}
+
+// parseTF reads the T or F of a search filter that can be left out.
+func parseTF(s string) *bool {
+	var b bool
+	switch s {
+	case "T":
+		b = true
+	case "F":
+		b = false
+	default:
+		return nil
+	}
+	return &b
+}
+
+func formatTF(b *bool) string {
+	switch {
+	case b == nil:
+		return ""
+	case *b:
+		return "T"
+	default:
+		return "F"
+	}
+}
@@ -0,0 +1,62 @@
+package ao3
+
+import (
+	"reflect"
+	"testing"
+
+	"golang.org/x/exp/slices"
+)
+
+func TestParseWorkSearch(t *testing.T) {
+	s, err := ParseWorkSearch(testSearchAll)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(s.Fandoms, []string{"Teen Wolf (TV)"}) {
+		t.Errorf("got fandoms %v", s.Fandoms)
+	}
+	if want := []string{"Derek Hale/Stiles Stilinski", "Derek Hale/Peter Hale"}; !slices.Equal(s.Relationships, want) {
+		t.Errorf("got relationships %v, expected %v", s.Relationships, want)
+	}
+	if s.Rating != RatingExplicit ||
+		!slices.Equal(s.Warnings, []ArchiveWarning{WarningChoseNotToUse, WarningNone}) ||
+		!slices.Equal(s.Categories, []Category{CategoryGen, CategoryMM}) {
+		t.Errorf("got rating %d, warnings %v, categories %v", s.Rating, s.Warnings, s.Categories)
+	}
+	if s.Words != (Range{Min: 2}) || s.Comments != (Range{Max: 3999999}) {
+		t.Errorf("got words %+v, comments %+v", s.Words, s.Comments)
+	}
+	if s.Complete != nil || s.Crossover == nil || *s.Crossover {
+		t.Errorf("got complete %v, crossover %v, expected any and no crossovers", s.Complete, s.Crossover)
+	}
+	if s.Language != "en" || s.SortColumn != SortBestMatch || s.SortDirection != SortDesc {
+		t.Errorf("got language %q, sort %q %q", s.Language, s.SortColumn, s.SortDirection)
+	}
+
+	again, err := ParseWorkSearch(s.String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(s, again) {
+		t.Errorf("round trip through %s\ngot %+v\nexpected %+v", s, again, s)
+	}
+}
+
+func TestRange(t *testing.T) {
+	for in, want := range map[string]Range{
+		"":          {},
+		">1":        {Min: 2},
+		"<4000000":  {Max: 3999999},
+		"1000-5000": {Min: 1000, Max: 5000},
+		"1,000":     {Min: 1000, Max: 1000},
+	} {
+		r := ParseRange(in)
+		if r != want {
+			t.Errorf("%q: got %+v, expected %+v", in, r, want)
+		}
+		if got := ParseRange(r.String()); got != r {
+			t.Errorf("%q: %+v formatted as %q parses to %+v", in, r, r.String(), got)
+		}
+	}
+}